@@ -9,7 +9,7 @@ import (
 	"os"
 	"time"
 
-	"github.com/rokath/trice/internal/args"
+	"github.com/rokath/trice/pkg/cli"
 )
 
 var (
@@ -31,13 +31,8 @@ func main() {
 // doit is the action.
 func doit() {
 
-	// inject values
-	args.Version = version
-	args.Commit = commit
-	args.Date = date
-
 	rand.Seed(time.Now().UnixNano())
-	err := args.Handler(os.Args)
+	err := cli.Run(os.Args, version, commit, date) // inject values
 	if nil != err {
 		fmt.Print(err)
 	}
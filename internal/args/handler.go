@@ -9,14 +9,17 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/rokath/trice/internal/com"
 	"github.com/rokath/trice/internal/decoder"
 	"github.com/rokath/trice/internal/emitter"
 	"github.com/rokath/trice/internal/id"
+	"github.com/rokath/trice/internal/keybcmd"
 	"github.com/rokath/trice/internal/link"
 	"github.com/rokath/trice/internal/receiver"
 	"github.com/rokath/trice/pkg/cage"
@@ -59,8 +62,7 @@ func Handler(args []string) error {
 	case "s", "scan":
 		msg.OnErr(fsScScan.Parse(subArgs))
 		distributeArgs()
-		_, err := com.GetSerialPorts()
-		return err
+		return com.Scan()
 	case "ver", "version":
 		msg.OnErr(fsScVersion.Parse(subArgs))
 		distributeArgs()
@@ -94,6 +96,23 @@ func Handler(args []string) error {
 		distributeArgs()
 		logLoop() // endless loop
 		return nil
+	case "replay":
+		var fn string
+		if 0 < len(subArgs) && !strings.HasPrefix(subArgs[0], "-") { // raw log file name in front of flags
+			fn, subArgs = subArgs[0], subArgs[1:]
+		}
+		msg.OnErr(fsScReplay.Parse(subArgs))
+		if "" == fn {
+			fn = fsScReplay.Arg(0)
+		}
+		if "" == fn {
+			return errors.New("no raw log file, try: 'trice h -replay'")
+		}
+		receiver.Port = "REPLAY"
+		receiver.PortArguments = fn
+		distributeArgs()
+		logLoop() // ends at raw log file end
+		return nil
 	}
 }
 
@@ -105,6 +124,14 @@ type selector struct {
 // logLoop prepares writing and lut and provides a retry mechanism for unplugged UART.
 func logLoop() {
 	msg.FatalOnErr(cipher.SetUp()) // does nothing when -password is ""
+	if _, _, e := decoder.Lookup(decoder.Encoding); nil != e {
+		fmt.Println(e)
+		return
+	}
+	if e := com.CheckReset(); nil != e {
+		fmt.Println(e)
+		return
+	}
 	if decoder.TestTableMode {
 		// set switches if they not set already
 		// trice l -ts off -prefix " }, ``" -suffix "\n``}," -color off
@@ -132,33 +159,221 @@ func logLoop() {
 	// This way trice needs NOT to be restarted during development process.
 	go lu.FileWatcher(m)
 
+	var rawLog io.WriteCloser // undecoded bytes recording
+	if "" != receiver.RawLog {
+		var err error
+		rawLog, err = receiver.NewRawLogFile(receiver.RawLog)
+		msg.FatalOnErr(err)
+		defer func() { msg.OnErr(rawLog.Close()) }()
+	}
+
+	if com.AutoBaud && receiver.IsSerial() {
+		if e := detectBaud(lu, m); nil != e {
+			fmt.Println(e)
+			return // hopeless
+		}
+	}
+
+	if keybcmd.Keyboard {
+		if "STDIN" == receiver.Port {
+			fmt.Println("-keybcmd is not usable together with port STDIN")
+			return
+		}
+		go func() { msg.OnErr(keybcmd.ReadInput(os.Stdin)) }()
+	}
+
+	emitter.ServerKeyboard = keybcmd.Keyboard // for an autostarted display server
 	sw := emitter.New()
-	var interrupted bool
+	if decoder.Stats {
+		defer func() { statusLine(sw, strings.TrimSuffix(decoder.StatsReport(), "\n")) }()
+		keybcmd.AtQuit = func() { fmt.Print(decoder.StatsReport()) } // sw is used by the decoding goroutine
+	}
+	if keybcmd.Keyboard && emitter.DisplayRemote {
+		go func() {
+			e := keybcmd.ReadRemote(emitter.NewRemoteDisplay(), 100*time.Millisecond)
+			if verbose {
+				fmt.Println(e)
+			}
+		}()
+	}
+	port := new(sessionPort)
+	done := make(chan struct{})
+	defer close(done)
+	go handleSignals(port, done) // one handler for the whole session, so also a signal during a re-connect ends trice
+	var connected bool           // true after the first successful connect
 	var counter int
+	retry := minRetryInterval
 
 	for {
 		rc, e := receiver.NewReadCloser(receiver.Port, receiver.PortArguments)
 		if nil != e {
-			fmt.Println(e)
-			if !interrupted {
+			if !connected {
+				fmt.Println(e)
 				cage.Stop(c)
 				return // hopeless
 			}
-			time.Sleep(1000 * time.Millisecond) // retry interval
-			fmt.Printf("\rsig:(re-)setup input port...%d", counter)
+			if verbose {
+				fmt.Println(e)
+			}
+			time.Sleep(retry)
+			retry = longer(retry)
+			if receiver.Relocate() {
+				fmt.Println("\nsig:USB serial number found again at", receiver.Port)
+			}
+			fmt.Printf("\rsig:(re-)setup input port %s...%d", receiver.Port, counter)
 			counter++
 			continue
 		}
-		defer func() { msg.OnErr(rc.Close()) }()
-		interrupted = true
+		if connected {
+			fmt.Println()
+			statusLine(sw, fmt.Sprint("sig:re-connected to ", receiver.Port))
+		} else if s, ok := rc.(com.Signaler); ok && "" != com.Reset {
+			msg.OnErr(com.ResetTarget(s, com.Reset)) // capture the trices from boot
+		}
+		connected = true
+		counter = 0
+		receiver.RememberPort()
+		if w, ok := rc.(io.Writer); ok && receiver.Writable() { // for commands to the target
+			keybcmd.SetTarget(w)
+		}
+		stop := make(chan struct{}) // ends the command file execution for this connection
+		if "" != keybcmd.CmdFile {
+			go func() { msg.OnErr(keybcmd.ExecuteFile(keybcmd.CmdFile, stop)) }()
+		}
 		if receiver.ShowInputBytes {
 			rc = receiver.NewBytesViewer(rc)
 		}
-		e = decoder.Translate(sw, lu, m, rc)
+		if nil != rawLog {
+			rc = receiver.NewRawLogger(rc, rawLog)
+		}
+		port.set(rc)
+		rx := &readCounter{ReadCloser: rc}
+		start := time.Now()
+		e = decoder.Translate(sw, lu, m, rx)
+		close(stop) // before the target is gone, so a running command file ends without error
+		keybcmd.SetTarget(nil)
+		port.close() // release the port before re-connecting
 		if io.EOF == e {
-			return // end of predefined buffer
+			return // end of predefined buffer or file
+		}
+		if receiver.Finite() { // a re-connect would start a predefined buffer or file again
+			statusLine(sw, fmt.Sprint("err:", receiver.Port, ": ", e))
+			return
+		}
+		statusLine(sw, fmt.Sprint("sig:lost connection to ", receiver.Port, ": ", e))
+		if 0 < rx.n || minConnectedTime <= time.Since(start) { // a working connection, like not a server closing each connection at once
+			retry = minRetryInterval
 		}
+		time.Sleep(retry)
+		retry = longer(retry)
+	}
+}
+
+// longer returns the doubled re-connect wait time retry limited to maxRetryInterval.
+func longer(retry time.Duration) time.Duration {
+	if retry *= 2; retry > maxRetryInterval {
+		retry = maxRetryInterval
+	}
+	return retry
+}
+
+// readCounter counts the bytes read from the port.
+type readCounter struct {
+	io.ReadCloser
+	n int64
+}
+
+// Read reads from the port and counts the bytes.
+func (p *readCounter) Read(b []byte) (n int, err error) {
+	n, err = p.ReadCloser.Read(b)
+	p.n += int64(n)
+	return
+}
+
+// Framed forwards the Framer property of the port.
+func (p *readCounter) Framed() bool {
+	f, ok := p.ReadCloser.(receiver.Framer)
+	return ok && f.Framed()
+}
+
+// sessionPort is the actual opened port of a log session.
+type sessionPort struct {
+	sync.Mutex
+	rc io.ReadCloser
+}
+
+// set remembers rc as actual port.
+func (p *sessionPort) set(rc io.ReadCloser) {
+	p.Lock()
+	p.rc = rc
+	p.Unlock()
+}
+
+// close closes the actual port, if any.
+func (p *sessionPort) close() {
+	p.Lock()
+	if nil != p.rc {
+		msg.OnErr(p.rc.Close())
+		p.rc = nil
+	}
+	p.Unlock()
+}
+
+// handleSignals closes the actual port and ends the program on CTRL-C or SIGTERM until done is closed.
+func handleSignals(port *sessionPort, done chan struct{}) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+	select {
+	case sig := <-sigs: // wait for a signal
+		if verbose {
+			fmt.Println("####################################", sig, "####################################")
+		}
+		port.close()
+		if decoder.Stats {
+			fmt.Print(decoder.StatsReport())
+		}
+		os.Exit(0) // end
+	case <-done:
+	}
+}
+
+// detectBaud receives with each of com.AutoBaudRates from the serial port and sets com.Baud to the rate giving the most valid trices.
+// Re-syncs between valid trices are a sign of a wrong rate, so a rate with too many of them is not accepted.
+func detectBaud(lu id.TriceIDLookUp, m *sync.RWMutex) error {
+	var best, bestValid int
+	for _, baud := range com.AutoBaudRates {
+		b, err := receiver.Sample(baud, com.AutoBaudTime)
+		if nil != err {
+			return err
+		}
+		valid, resyncs := decoder.Plausibility(lu, m, b)
+		if verbose {
+			fmt.Println("baud rate", baud, ":", len(b), "bytes,", valid, "valid trices,", resyncs, "re-syncs")
+		}
+		if 2*resyncs < valid && bestValid < valid {
+			best, bestValid = baud, valid
+		}
+		if 0 == resyncs && 3 <= valid { // clean stream, no need to try further
+			break
+		}
+	}
+	if 0 == bestValid {
+		return fmt.Errorf("no baud rate found with valid trices on %s, the target needs to send trices or sync packets during the detection", receiver.Port)
+	}
+	com.Baud = best
+	fmt.Println("sig:detected baud rate", best)
+	return nil
+}
+
+// statusLine writes s as separate line with sw. A started trice line is completed before.
+func statusLine(sw *emitter.TriceLineComposer, s string) {
+	if 0 < len(sw.Line) {
+		_, err := sw.WriteString("\n")
+		msg.OnErr(err)
 	}
+	_, err := sw.WriteString(s + "\n")
+	msg.OnErr(err)
 }
 
 // scVersion is subcommand 'version'. It prints version information.
@@ -181,8 +396,10 @@ func scVersion() error {
 func distributeArgs() {
 	replaceDefaultArgs()
 	com.Verbose = verbose
+	receiver.Verbose = verbose
 	id.Verbose = verbose
 	link.Verbose = verbose
+	keybcmd.Verbose = verbose
 	cage.Verbose = verbose
 	decoder.Verbose = verbose
 	emitter.Verbose = verbose
@@ -4,13 +4,18 @@
 package args
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/rokath/trice/internal/id"
+	"github.com/rokath/trice/internal/receiver"
 	"github.com/rokath/trice/pkg/msg"
 
 	"github.com/rokath/trice/pkg/tst"
@@ -185,6 +190,39 @@ func Example_log() {
 	// can not open COMX
 }
 
+func TestReplayTruncated(t *testing.T) {
+	fn := getTemporaryFileName("trice-*.trb")
+	defer func() { assert.Nil(t, os.Remove(fn)) }()
+	w, err := receiver.NewRawLogFile(fn)
+	assert.Nil(t, err)
+	rc := receiver.NewRawLogger(ioutil.NopCloser(io.MultiReader(bytes.NewReader([]byte{1, 2, 3, 4}), bytes.NewReader([]byte{5, 6, 7, 8}))), w)
+	_, err = ioutil.ReadAll(rc)
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+	fi, err := os.Stat(fn)
+	assert.Nil(t, err)
+	assert.Nil(t, os.Truncate(fn, fi.Size()-2)) // second record incomplete
+
+	defer func(port, args, speed string) { // replay sets them
+		receiver.Port, receiver.PortArguments, receiver.ReplaySpeed = port, args, speed
+	}(receiver.Port, receiver.PortArguments, receiver.ReplaySpeed)
+	done := make(chan string)
+	go func() {
+		m.Lock()
+		done <- tst.CaptureStdOut(func() {
+			assert.Nil(t, Handler([]string{"trice", "replay", fn, "-speed", "max", "-ts", "off", "-color", "off", "-idlist", id.FnJSON}))
+		})
+		m.Unlock()
+	}()
+	select {
+	case act := <-done:
+		assert.Equal(t, 1, strings.Count(act, "truncated trice raw log record"), act)
+		assert.False(t, strings.Contains(act, "lost connection"), act)
+	case <-time.After(5 * time.Second):
+		t.Fatal("replay of a truncated raw log does not end")
+	}
+}
+
 func Example_help_a() {
 	fn := func() {
 		err := Handler([]string{"trice", "help"})
@@ -249,7 +287,7 @@ func TestHelp(t *testing.T) {
 	m.Unlock()
 	h.Unlock()
 	fmt.Print(act)
-	exp := "syntax: 'trice subcommand' [params]\nsubcommand 'h|help': For command line usage.\n\t\"trice h\" will print this help text as a whole.\n  -all\n    \tShow all help.\n  -displayserver\n    \tShow ds|displayserver specific help.\n  -ds\n    \tShow ds|displayserver specific help.\n  -h\tShow h|help specific help.\n  -help\n    \tShow h|help specific help.\n  -l\tShow l|log specific help.\n  -log\n    \tShow l|log specific help.\n  -logfile string\n    \tAppend all output to logfile. Options are: 'off|none|filename|auto':\n    \t\"off\": no logfile (same as \"none\")\n    \t\"none\": no logfile (same as \"off\")\n    \t\"auto\": Use as logfile name \"2006-01-02_1504-05_trice.log\" with actual time.\n    \t\"filename\": Any other string than \"auto\", \"none\" or \"off\" is treated as a filename. If the file exists, logs are appended.\n    \tAll trice output of the appropriate subcommands is appended per default into the logfile trice additionally to the normal output.\n    \tChange the filename with \"-logfile myName.txt\" or switch logging off with \"-logfile none\".\n    \t (default \"off\")\n  -r\tShow r|refresh specific help.\n  -refresh\n    \tShow r|refresh specific help.\n  -renew\n    \tShow renew specific help.\n  -s\tShow s|scan specific help.\n  -scan\n    \tShow s|scan specific help.\n  -sd\n    \tShow sd|shutdown specific help.\n  -shutdown\n    \tShow sd|shutdown specific help.\n  -u\tShow u|update specific help.\n  -update\n    \tShow u|update specific help.\n  -v\tshort for verbose\n  -ver\n    \tShow ver|version specific help.\n  -verbose\n    \tGives more informal output if used. Can be helpful during setup.\n    \tFor example \"trice u -dry-run -v\" is the same as \"trice u -dry-run\" but with more descriptive output.\n    \tThis is a bool switch. It has no parameters. Its default value is false. If the switch is applied its value is true.\n  -version\n    \tShow ver|version specific help.\n  -z\tShow zeroSourceTreeIds specific help.\n  -zeroSourceTreeIds\n    \tShow zeroSourceTreeIds specific help.\nexample 'trice h': Print short help.\nexample 'trice h -all': Print all help.\nexample 'trice h -log': Print log help.\n"
+	exp := "syntax: 'trice subcommand' [params]\nsubcommand 'h|help': For command line usage.\n\t\"trice h\" will print this help text as a whole.\n  -all\n    \tShow all help.\n  -displayserver\n    \tShow ds|displayserver specific help.\n  -ds\n    \tShow ds|displayserver specific help.\n  -h\tShow h|help specific help.\n  -help\n    \tShow h|help specific help.\n  -l\tShow l|log specific help.\n  -log\n    \tShow l|log specific help.\n  -logfile string\n    \tAppend all output to logfile. Options are: 'off|none|filename|auto':\n    \t\"off\": no logfile (same as \"none\")\n    \t\"none\": no logfile (same as \"off\")\n    \t\"auto\": Use as logfile name \"2006-01-02_1504-05_trice.log\" with actual time.\n    \t\"filename\": Any other string than \"auto\", \"none\" or \"off\" is treated as a filename. If the file exists, logs are appended.\n    \tAll trice output of the appropriate subcommands is appended per default into the logfile trice additionally to the normal output.\n    \tChange the filename with \"-logfile myName.txt\" or switch logging off with \"-logfile none\".\n    \t (default \"off\")\n  -r\tShow r|refresh specific help.\n  -refresh\n    \tShow r|refresh specific help.\n  -renew\n    \tShow renew specific help.\n  -replay\n    \tShow replay specific help.\n  -s\tShow s|scan specific help.\n  -scan\n    \tShow s|scan specific help.\n  -sd\n    \tShow sd|shutdown specific help.\n  -shutdown\n    \tShow sd|shutdown specific help.\n  -u\tShow u|update specific help.\n  -update\n    \tShow u|update specific help.\n  -v\tshort for verbose\n  -ver\n    \tShow ver|version specific help.\n  -verbose\n    \tGives more informal output if used. Can be helpful during setup.\n    \tFor example \"trice u -dry-run -v\" is the same as \"trice u -dry-run\" but with more descriptive output.\n    \tThis is a bool switch. It has no parameters. Its default value is false. If the switch is applied its value is true.\n  -version\n    \tShow ver|version specific help.\n  -z\tShow zeroSourceTreeIds specific help.\n  -zeroSourceTreeIds\n    \tShow zeroSourceTreeIds specific help.\nexample 'trice h': Print short help.\nexample 'trice h -all': Print all help.\nexample 'trice h -log': Print log help.\n"
 	assert.Equal(t, exp, act)
 }
 
@@ -295,6 +333,9 @@ func TestHelpAll(t *testing.T) {
                 16 bit IP port number.
                 You can specify this swich if you want to change the used port number for the remote display functionality.
                  (default "61497")
+        -keybcmd
+                Read command lines from the keyboard (stdin) for a "trice log -keybcmd -displayserver" instance, which sends them to the target.
+                This is a bool switch. It has no parameters. Its default value is false. If the switch is applied its value is true.
         -logfile string
                 Append all output to logfile. Options are: 'off|none|filename|auto':
                 "off": no logfile (same as "none")
@@ -333,6 +374,8 @@ func TestHelpAll(t *testing.T) {
                 Show r|refresh specific help.
         -renew
                 Show renew specific help.
+        -replay
+                Show replay specific help.
         -s	Show s|scan specific help.
         -scan
                 Show s|scan specific help.
@@ -363,6 +406,9 @@ func TestHelpAll(t *testing.T) {
         -args string
                 Use to pass port specific parameters. The "default" value depends on the used port:
                 port "COMn": default="", use "TARM" for a different driver. (For baud rate settings see -baud.)
+                      The serial line settings are also possible as comma separated list in the order baud rate, data bits, parity, stop bits and "rtscts",
+                      example: "115200,8,E,1,rtscts". Omitted values keep the flag settings, so "TARM,9600" is valid too. "rtscts" works only on Linux.
+                      Outside Windows the TARM driver does not support mark and space parity and 1.5 stop bits.
                 port "J-LINK": default="-Device STM32F030R8 -if SWD -Speed 4000 -RTTChannel 0 -RTTSearchRanges 0x20000000_0x1000", 
                       The -RTTSearchRanges "..." need to be written without "" and with _ istead of space.
                       For args options see JLinkRTTLogger in SEGGER UM08001_JLink.pdf.
@@ -370,16 +416,32 @@ func TestHelpAll(t *testing.T) {
                       The -RTTSearchRanges "..." need to be written without "" and with _ istead of space.
                       For args options see JLinkRTTLogger in SEGGER UM08001_JLink.pdf.
                 port "BUFFER": default="0 0 0 0", Option for args is any byte sequence.
+                port "TCP4:host:port"|"TCP6:host:port": args are ignored.
+                port "UDP:ip:port": args "frame" handles each datagram as frame: Bytes not forming a complete trice at a datagram end are discarded.
+                      Datagrams longer than 4096 bytes are discarded with an error message.
+                port "FILE": args is the file name.
+                port "STDIN"|"FIFO:path": args are ignored.
+                port "CMD": args is the command line. Use quotes around parts containing spaces.
+                port "OPENOCD:host:port": args are ignored, the RTT channel is selected with the OpenOCD "rtt server start" command.
+                port "JLINKRTT[:host[:port]]": args is the RTT channel number, "default" is channel 0.
                  (default "default")
         -autostart
                 Autostart displayserver @ ipa:ipp.
                 Works not perfect with windows, because of cmd and powershell color issues and missing cli params in wt and gitbash.
                 Example: "trice l -port COM38 -displayserver -autostart" opens a separate display window automatically on the same PC.
                 This is a bool switch. It has no parameters. Its default value is false. If the switch is applied its value is true.
-        -baud int
+        -baud value
                 Set the serial port baudrate.
-                It is the only setup parameter. The other values default to 8N1 (8 data bits, no parity, one stopbit).
+                Use "auto" for trying common baud rates until the decoder finds valid trices, example: "trice l -p COM3 -baud auto".
+                A baud rate in -args is ignored then, but the other line settings there are used.
+                The other line settings default to 8N1 (8 data bits, no parity, one stopbit), see -databits, -parity, -stopbits and -rtscts.
                  (default 115200)
+        -cmdeol string
+                Line ending appended to each command line sent to the target, options: 'CRLF|CR|LF|none'. (default "CRLF")
+        -cmdfile string
+                Send the command lines in this file to the target after each connect. A still running command file ends with the connection. Empty lines and lines starting with "#" are ignored.
+                Local commands like "!sleep 100ms" are possible. Default is "" for no command file.
+                
         -color string
                 The format strings can start with a lower or upper case channel information.
                 See https://github.com/rokath/trice/blob/master/pkg/src/triceCheck.c for examples. Color options: 
@@ -387,6 +449,8 @@ func TestHelpAll(t *testing.T) {
                 "none": Disable ANSI color. The lower case channel information is removed: "w:x"-> "x"
                 "default|color": Use ANSI color codes for known upper and lower case channel info are inserted and lower case channel information is removed.
                  (default "default")
+        -databits int
+                Set the serial port data bits count, options: '5|6|7|8'. (default 8)
         -displayserver
                 Send trice lines to displayserver @ ipa:ipp.
                 Example: "trice l -port COM38 -ds -ipa 192.168.178.44" sends trice output to a previously started display server in the same network.
@@ -395,7 +459,15 @@ func TestHelpAll(t *testing.T) {
         -e string
                 Short for -encoding. (default "flexL")
         -encoding string
-                The trice transmit data format type, options: 'esc|ESC|(flex|FLEX)[(l|L)'. Target device encoding must match. (default "flexL")
+                The trice transmit data format type, options: 'esc|flex|flexL|flexCRC|flexCRCL|pack2|pack2L'. Names are case insensitive. Target device encoding must match.
+                esc: Escape sequence based encoding with 0xEC as start byte, big endian only.
+                flex|flexL: Flexible encoding with 15-bit and 20-bit IDs and sync packets, use 'flexL' for little endian targets.
+                flexCRC|flexCRCL: Flex encoding with a CRC-8 trailer after short and a CRC-16 trailer after medium and long trices, use 'flexCRCL' for little endian targets.
+                pack2|pack2L: Encoding with 20-bit IDs, cycle counter and runtime strings up to 65535 chars, use 'pack2L' for little endian targets.
+                +cobs: Append to an encoding name like 'flexL+cobs' for COBS framed trices with 0 as frame delimiter. A corrupted frame is discarded as a whole.
+                 (default "flexL")
+        -follow
+                Keep reading port "FILE" at its end like "tail -f" for still growing files. Without it the log ends at the file end. This is a bool switch. It has no parameters. Its default value is false. If the switch is applied its value is true.
         -i string
                 Short for '-idlist'.
                  (default "til.json")
@@ -414,6 +486,12 @@ func TestHelpAll(t *testing.T) {
                 16 bit IP port number.
                 You can specify this swich if you want to change the used port number for the remote display functionality.
                  (default "61497")
+        -keybcmd
+                Read command lines from the keyboard (stdin) during logging and send them to the target, for example to use its command shell.
+                This works for serial ports and "TCP4:host:port" connections. With -displayserver the lines typed in the display server window are sent too,
+                when the display server was started with -autostart or with "trice ds -keybcmd".
+                Lines starting with "!" are local commands, type "!help" for a list. Not usable together with port "STDIN".
+                This is a bool switch. It has no parameters. Its default value is false. If the switch is applied its value is true.
         -logfile string
                 Append all output to logfile. Options are: 'off|none|filename|auto':
                 "off": no logfile (same as "none")
@@ -425,18 +503,44 @@ func TestHelpAll(t *testing.T) {
                  (default "off")
         -p string
                 short for -port (default "J-LINK")
+        -parity string
+                Set the serial port parity, options: 'N|E|O|M|S' or 'none|even|odd|mark|space'. (default "N")
         -password string
                 The decrypt passphrase. If you change this value you need to compile the target with the appropriate key (see -showKeys).
                 Encryption is recommended if you deliver firmware to customers and want protect the trice log output. This does work right now only with flex and flexL format.
         -port string
-                receiver device: 'ST-LINK'|'J-LINK'|serial name. 
+                receiver device: 'ST-LINK'|'J-LINK'|'TCP4:host:port'|'TCP6:host:port'|'UDP:ip:port'|'FILE'|'STDIN'|'FIFO:path'|'CMD'|'OPENOCD:host:port'|'JLINKRTT[:host[:port]]'|serial name. 
                 The serial name is like 'COM12' for Windows or a Linux name like '/dev/tty/usb12'. 
                 Using a virtual serial COM port on the PC over a FTDI USB adapter is a most likely variant.
+                A lost serial port is re-opened when it appears again, also under a different name if it has the same USB serial number.
+                On Linux serial line errors like framing, parity and overrun errors are shown as "err:" lines together with the decoder re-syncs they caused.
+                "usb:VID:PID[:serial]" selects a USB serial adapter independent of its name, example: "usb:0403:6001:A50285BI". See "trice s" for the values.
+                "TCP4:host:port" connects to a TCP server like ser2net or a WiFi bridge, example: "TCP4:192.168.1.7:2217". A lost connection is re-established.
+                "UDP:ip:port" listens for datagrams, example: "UDP:0.0.0.0:17001". Use "UDP4" or "UDP6" to restrict the IP version. A 'source:' prefix shows the sender address.
+                "FILE" reads raw trice bytes from the file given with -args, for example a JLinkRTTLogger output file. See also -follow.
+                "STDIN" reads from standard input, example: "ssh board cat /dev/ttyS1 | trice l -port STDIN". "FIFO:path" reads from a named pipe. Both end at the input end.
+                "CMD" starts the command given with -args and reads its stdout, example: -port CMD -args "openocd -f board.cfg". Logging ends with the command.
+                "OPENOCD:host:port" connects to an OpenOCD RTT server started with "rtt server start 9090 0", example: "OPENOCD:localhost:9090".
+                "JLINKRTT" connects to the J-Link RTT telnet server of a running J-Link GDB server, default is "JLINKRTT:localhost:19021".
                  (default "J-LINK")
         -prefix string
                 Line prefix, options: any string or 'off|none' or 'source:' followed by 0-12 spaces, 'source:' will be replaced by source value e.g., 'COM17:'. (default "source: ")
         -pw string
                 Short for -password.
+        -rawlog string
+                Record the undecoded input bytes with their arrival times into a raw log file like "capture.trb".
+                Use "trice replay capture.trb" later to decode them again, for example with a corrected til.json or a different -encoding. Default is "" for no recording.
+                
+        -reset string
+                Reset the target after opening the serial port, so the trices from boot are captured, options: 'DTR|RTS|DTR+RTS|break|none'.
+                A line like "DTR" is released, asserted for -resettime and released again, what fits targets wired like Arduino-style auto-reset.
+                "break" holds the serial line in break condition for -resettime. Only the first open resets the target, not a re-connect.
+                With -keybcmd the local command "!reset" does this again during logging. Default is "" for no reset.
+                
+        -resettime duration
+                Duration of the -reset pulse or break. (default 100ms)
+        -rtscts
+                Use RTS/CTS hardware flow control on the serial port. This is supported only on Linux. This is a bool switch. It has no parameters. Its default value is false. If the switch is applied its value is true.
         -s	Short for '-showInputBytes'.
         -showID string
                 Format string for displaying first trice ID at start of each line. Example: "debug:%7d ". Default is "". If several trices form a log line only the first trice ID ist displayed.
@@ -447,6 +551,15 @@ func TestHelpAll(t *testing.T) {
                 Show encryption key. Use this switch for creating your own password keys. If applied together with "-password MySecret" it shows the encryption key.
                 Simply copy this key than into the line "#define ENCRYPT XTEA_KEY( ea, bb, ec, 6f, 31, 80, 4e, b9, 68, e2, fa, ea, ae, f1, 50, 54 ); //!< -password MySecret" inside triceConfig.h.
                 This is a bool switch. It has no parameters. Its default value is false. If the switch is applied its value is true.
+        -stats
+                Report decoder statistics as "inf:" lines at exit: decoded trices per ID and channel, discarded bytes,
+                decoder errors per reason, lost trices estimated from cycle counter gaps, unknown IDs, sync packets, re-syncs and serial line errors.
+                This is a bool switch. It has no parameters. Its default value is false. If the switch is applied its value is true.
+        -statsinterval duration
+                Report the -stats statistics also periodically during logging, example: "-statsinterval 1m". 0 is for only at exit.
+                
+        -stopbits string
+                Set the serial port stop bits count, options: '1|1.5|2'. (default "1")
         -suffix string
                 Append suffix to all lines, options: any string.
         -testTable
@@ -549,8 +662,98 @@ func TestHelpAll(t *testing.T) {
                 For example "trice u -dry-run -v" is the same as "trice u -dry-run" but with more descriptive output.
                 This is a bool switch. It has no parameters. Its default value is false. If the switch is applied its value is true.
       example: 'trice renew': Rebuild ID list from source tree, discard old IDs.
-      subcommand 's|scan': Shows available serial ports)
+      subcommand 'replay': For decoding a raw log file recorded with "trice log -rawlog file.trb".
+            The recorded bytes are decoded again with their original timing or faster, see -speed.
+            The raw log file name is the first parameter after the subcommand.
+        -color string
+                The format strings can start with a lower or upper case channel information.
+                See https://github.com/rokath/trice/blob/master/pkg/src/triceCheck.c for examples. Color options: 
+                "off": Disable ANSI color. The lower case channel information is kept: "w:x"-> "w:x" 
+                "none": Disable ANSI color. The lower case channel information is removed: "w:x"-> "x"
+                "default|color": Use ANSI color codes for known upper and lower case channel info are inserted and lower case channel information is removed.
+                 (default "default")
+        -displayserver
+                Send trice lines to displayserver @ ipa:ipp.
+        -ds
+                Short for '-displayserver'.
+        -e string
+                Short for -encoding. (default "flexL")
+        -encoding string
+                The trice transmit data format type, options: 'esc|flex|flexL|flexCRC|flexCRCL|pack2|pack2L'. Names are case insensitive. Must match the recorded encoding.
+                esc: Escape sequence based encoding with 0xEC as start byte, big endian only.
+                flex|flexL: Flexible encoding with 15-bit and 20-bit IDs and sync packets, use 'flexL' for little endian targets.
+                flexCRC|flexCRCL: Flex encoding with a CRC-8 trailer after short and a CRC-16 trailer after medium and long trices, use 'flexCRCL' for little endian targets.
+                pack2|pack2L: Encoding with 20-bit IDs, cycle counter and runtime strings up to 65535 chars, use 'pack2L' for little endian targets.
+                +cobs: Append to an encoding name like 'flexL+cobs' for COBS framed trices with 0 as frame delimiter. A corrupted frame is discarded as a whole.
+                 (default "flexL")
+        -i string
+                Short for '-idlist'.
+                 (default "til.json")
+        -idList string
+                Alternate for '-idlist'.
+                 (default "til.json")
+        -idlist string
+                The trice ID list file.
+                The specified JSON file is needed to display the ID coded trices during runtime and should be under version control.
+                 (default "til.json")
+        -ipa string
+                IP address like '127.0.0.1'.
+                You can specify this swich if you intend to use the remote display option to show the output on a different PC in the network.
+                 (default "localhost")
+        -ipp string
+                16 bit IP port number.
+                You can specify this swich if you want to change the used port number for the remote display functionality.
+                 (default "61497")
+        -logfile string
+                Append all output to logfile. Options are: 'off|none|filename|auto':
+                "off": no logfile (same as "none")
+                "none": no logfile (same as "off")
+                "auto": Use as logfile name "2006-01-02_1504-05_trice.log" with actual time.
+                "filename": Any other string than "auto", "none" or "off" is treated as a filename. If the file exists, logs are appended.
+                All trice output of the appropriate subcommands is appended per default into the logfile trice additionally to the normal output.
+                Change the filename with "-logfile myName.txt" or switch logging off with "-logfile none".
+                 (default "off")
+        -password string
+                The decrypt passphrase, see 'trice h -log'.
+        -prefix string
+                Line prefix, see 'trice h -log'. (default "source: ")
+        -pw string
+                Short for -password.
+        -showID string
+                Format string for displaying first trice ID at start of each line. Example: "debug:%7d ".
+        -speed string
+                Replay speed, options: '1x|10x|max':
+                "1x" replays with the recorded timing, "10x" 10 times faster and "max" without any waiting. Any factor like "0.5x" is possible.
+                 (default "1x")
+        -stats
+                Report decoder statistics as "inf:" lines at exit: decoded trices per ID and channel, discarded bytes,
+                decoder errors per reason, lost trices estimated from cycle counter gaps, unknown IDs, sync packets, re-syncs and serial line errors.
+                This is a bool switch. It has no parameters. Its default value is false. If the switch is applied its value is true.
+        -statsinterval duration
+                Report the -stats statistics also periodically during logging, example: "-statsinterval 1m". 0 is for only at exit.
+                
+        -suffix string
+                Append suffix to all lines, options: any string.
+        -til string
+                Short for '-idlist'.
+                 (default "til.json")
+        -ts string
+                PC timestamp for logs, options: 'off|none|UTCmicro|zero'. The timestamps are the replay times. (default "LOCmicro")
+        -u	Short for '-unsignedHex'.
+        -unsignedHex
+                Hex and Bin values are printed as unsigned values.
+        -v	short for verbose
+        -verbose
+                Gives more informal output if used. Can be helpful during setup.
+                For example "trice u -dry-run -v" is the same as "trice u -dry-run" but with more descriptive output.
+                This is a bool switch. It has no parameters. Its default value is false. If the switch is applied its value is true.
+      example: 'trice replay capture.trb -speed 10x': Display the trice logs recorded in capture.trb 10 times faster.
+      example: 'trice replay capture.trb -speed max -encoding flex -idlist old/til.json': Decode capture.trb immediately with different settings.
+      subcommand 's|scan': Shows available serial ports with USB VID, PID, serial number, manufacturer and product if available.
+        -json
+                Print the serial port list with USB data as JSON. This is a bool switch. It has no parameters. Its default value is false. If the switch is applied its value is true.
       example: 'trice s': Show COM ports.
+      example: 'trice s -json': Show COM ports as JSON.
       subcommand 'sd|shutdown': Ends display server at IPA:IPP, works also on a remote mashine.
         -ipa string
                 IP address like '127.0.0.1'.
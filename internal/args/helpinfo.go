@@ -20,6 +20,7 @@ func scHelp() error {
 	}
 	cage.Enable()
 	defer cage.Disable()
+	updateEncodingInfo()
 
 	fmt.Println("syntax: 'trice subcommand' [params]")
 	var ok bool
@@ -29,6 +30,7 @@ func scHelp() error {
 		{allHelp || logHelp, logInfo},
 		{allHelp || refreshHelp, refreshInfo},
 		{allHelp || renewHelp, renewInfo},
+		{allHelp || replayHelp, replayInfo},
 		{allHelp || scanHelp, scanInfo},
 		{allHelp || shutdownHelp, shutdownInfo},
 		{allHelp || versionHelp, versionInfo},
@@ -105,11 +107,23 @@ func renewInfo() error {
 	return e
 }
 
+func replayInfo() error {
+	_, e := fmt.Println(`subcommand 'replay': For decoding a raw log file recorded with "trice log -rawlog file.trb".
+	The recorded bytes are decoded again with their original timing or faster, see -speed.
+	The raw log file name is the first parameter after the subcommand.`)
+	fsScReplay.SetOutput(os.Stdout)
+	fsScReplay.PrintDefaults()
+	fmt.Println("example: 'trice replay capture.trb -speed 10x': Display the trice logs recorded in capture.trb 10 times faster.")
+	fmt.Println("example: 'trice replay capture.trb -speed max -encoding flex -idlist old/til.json': Decode capture.trb immediately with different settings.")
+	return e
+}
+
 func scanInfo() error {
-	_, e := fmt.Println(`subcommand 's|scan': Shows available serial ports)`)
+	_, e := fmt.Println(`subcommand 's|scan': Shows available serial ports with USB VID, PID, serial number, manufacturer and product if available.`)
 	fsScScan.SetOutput(os.Stdout)
 	fsScScan.PrintDefaults()
 	fmt.Println("example: 'trice s': Show COM ports.")
+	fmt.Println("example: 'trice s -json': Show COM ports as JSON.")
 	return e
 }
 
@@ -6,11 +6,15 @@ package args
 import (
 	"flag"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/rokath/trice/internal/com"
 	"github.com/rokath/trice/internal/decoder"
 	"github.com/rokath/trice/internal/emitter"
 	"github.com/rokath/trice/internal/id"
+	"github.com/rokath/trice/internal/keybcmd"
 	"github.com/rokath/trice/internal/receiver"
 	"github.com/rokath/trice/pkg/cage"
 	"github.com/rokath/trice/pkg/cipher"
@@ -40,6 +44,7 @@ func init() {
 	fsScHelp.BoolVar(&refreshHelp, "refresh", false, "Show r|refresh specific help.")
 	fsScHelp.BoolVar(&refreshHelp, "r", false, "Show r|refresh specific help.")
 	fsScHelp.BoolVar(&renewHelp, "renew", false, "Show renew specific help.")
+	fsScHelp.BoolVar(&replayHelp, "replay", false, "Show replay specific help.")
 	fsScHelp.BoolVar(&scanHelp, "scan", false, "Show s|scan specific help.")
 	fsScHelp.BoolVar(&scanHelp, "s", false, "Show s|scan specific help.")
 	fsScHelp.BoolVar(&shutdownHelp, "shutdown", false, "Show sd|shutdown specific help.")
@@ -55,9 +60,9 @@ func init() {
 }
 
 func init() {
-	fsScLog = flag.NewFlagSet("log", flag.ExitOnError)                                                                                                                         // subcommand
-	fsScLog.StringVar(&decoder.Encoding, "encoding", "flexL", "The trice transmit data format type, options: 'esc|ESC|(flex|FLEX)[(l|L)'. Target device encoding must match.") // flag
-	fsScLog.StringVar(&decoder.Encoding, "e", "flexL", "Short for -encoding.")                                                                                                 // short flag
+	fsScLog = flag.NewFlagSet("log", flag.ExitOnError)                                       // subcommand
+	fsScLog.StringVar(&decoder.Encoding, "encoding", "flexL", encodingInfo(logEncodingInfo)) // flag
+	fsScLog.StringVar(&decoder.Encoding, "e", "flexL", "Short for -encoding.")               // short flag
 	fsScLog.StringVar(&cipher.Password, "password", "", `The decrypt passphrase. If you change this value you need to compile the target with the appropriate key (see -showKeys).
 Encryption is recommended if you deliver firmware to customers and want protect the trice log output. This does work right now only with flex and flexL format.`) // flag
 	fsScLog.StringVar(&cipher.Password, "pw", "", "Short for -password.") // short flag
@@ -78,16 +83,39 @@ If you need target timestamps you need to get the time inside the target and sen
 	fsScLog.StringVar(&emitter.Prefix, "prefix", DefaultPrefix, "Line prefix, options: any string or 'off|none' or 'source:' followed by 0-12 spaces, 'source:' will be replaced by source value e.g., 'COM17:'.") // flag
 	fsScLog.StringVar(&emitter.Suffix, "suffix", "", "Append suffix to all lines, options: any string.")                                                                                                           // flag
 
-	info := fmt.Sprint(`receiver device: 'ST-LINK'|'J-LINK'|serial name. 
+	info := fmt.Sprint(`receiver device: 'ST-LINK'|'J-LINK'|'TCP4:host:port'|'TCP6:host:port'|'UDP:ip:port'|'FILE'|'STDIN'|'FIFO:path'|'CMD'|'OPENOCD:host:port'|'JLINKRTT[:host[:port]]'|serial name. 
 The serial name is like 'COM12' for Windows or a Linux name like '/dev/tty/usb12'. 
 Using a virtual serial COM port on the PC over a FTDI USB adapter is a most likely variant.
+A lost serial port is re-opened when it appears again, also under a different name if it has the same USB serial number.
+On Linux serial line errors like framing, parity and overrun errors are shown as "err:" lines together with the decoder re-syncs they caused.
+"usb:VID:PID[:serial]" selects a USB serial adapter independent of its name, example: "usb:0403:6001:A50285BI". See "trice s" for the values.
+"TCP4:host:port" connects to a TCP server like ser2net or a WiFi bridge, example: "TCP4:192.168.1.7:2217". A lost connection is re-established.
+"UDP:ip:port" listens for datagrams, example: "UDP:0.0.0.0:17001". Use "UDP4" or "UDP6" to restrict the IP version. A 'source:' prefix shows the sender address.
+"FILE" reads raw trice bytes from the file given with -args, for example a JLinkRTTLogger output file. See also -follow.
+"STDIN" reads from standard input, example: "ssh board cat /dev/ttyS1 | trice l -port STDIN". "FIFO:path" reads from a named pipe. Both end at the input end.
+"CMD" starts the command given with -args and reads its stdout, example: -port CMD -args "openocd -f board.cfg". Logging ends with the command.
+"OPENOCD:host:port" connects to an OpenOCD RTT server started with "rtt server start 9090 0", example: "OPENOCD:localhost:9090".
+"JLINKRTT" connects to the J-Link RTT telnet server of a running J-Link GDB server, default is "JLINKRTT:localhost:19021".
 `)
 
 	fsScLog.StringVar(&receiver.Port, "port", "J-LINK", info)           // flag
 	fsScLog.StringVar(&receiver.Port, "p", "J-LINK", "short for -port") // short flag
-	fsScLog.IntVar(&com.Baud, "baud", 115200, `Set the serial port baudrate.
-It is the only setup parameter. The other values default to 8N1 (8 data bits, no parity, one stopbit).
+	com.Baud = 115200
+	fsScLog.Var(baudValue{&com.Baud, &com.AutoBaud}, "baud", `Set the serial port baudrate.
+Use "auto" for trying common baud rates until the decoder finds valid trices, example: "trice l -p COM3 -baud auto".
+A baud rate in -args is ignored then, but the other line settings there are used.
+The other line settings default to 8N1 (8 data bits, no parity, one stopbit), see -databits, -parity, -stopbits and -rtscts.
 `) // flag flag
+	fsScLog.IntVar(&com.DataBits, "databits", 8, "Set the serial port data bits count, options: '5|6|7|8'.")                         // flag
+	fsScLog.StringVar(&com.Parity, "parity", "N", "Set the serial port parity, options: 'N|E|O|M|S' or 'none|even|odd|mark|space'.") // flag
+	fsScLog.StringVar(&com.StopBits, "stopbits", "1", "Set the serial port stop bits count, options: '1|1.5|2'.")                    // flag
+	fsScLog.StringVar(&com.Reset, "reset", "", `Reset the target after opening the serial port, so the trices from boot are captured, options: 'DTR|RTS|DTR+RTS|break|none'.
+A line like "DTR" is released, asserted for -resettime and released again, what fits targets wired like Arduino-style auto-reset.
+"break" holds the serial line in break condition for -resettime. Only the first open resets the target, not a re-connect.
+With -keybcmd the local command "!reset" does this again during logging. Default is "" for no reset.
+`) // flag
+	fsScLog.DurationVar(&com.ResetTime, "resettime", 100*time.Millisecond, "Duration of the -reset pulse or break.")                                  // flag
+	fsScLog.BoolVar(&com.RTSCTS, "rtscts", false, "Use RTS/CTS hardware flow control on the serial port. This is supported only on Linux. "+boolInfo) // flag
 
 	linkArgsInfo := `
 	The -RTTSearchRanges "..." need to be written without "" and with _ istead of space.
@@ -95,12 +123,24 @@ It is the only setup parameter. The other values default to 8N1 (8 data bits, no
 
 	argsInfo := fmt.Sprint(`Use to pass port specific parameters. The "default" value depends on the used port:
 port "COMn": default="`, defaultCOMArgs, `", use "TARM" for a different driver. (For baud rate settings see -baud.)
+	The serial line settings are also possible as comma separated list in the order baud rate, data bits, parity, stop bits and "rtscts",
+	example: "115200,8,E,1,rtscts". Omitted values keep the flag settings, so "TARM,9600" is valid too. "rtscts" works only on Linux.
+	Outside Windows the TARM driver does not support mark and space parity and 1.5 stop bits.
 port "J-LINK": default="`, defaultLinkArgs, `", `, linkArgsInfo, `
 port "ST-LINK": default="`, defaultLinkArgs, `", `, linkArgsInfo, `
 port "BUFFER": default="`, defaultBUFFERArgs, `", Option for args is any byte sequence.
+port "TCP4:host:port"|"TCP6:host:port": args are ignored.
+port "UDP:ip:port": args "frame" handles each datagram as frame: Bytes not forming a complete trice at a datagram end are discarded.
+	Datagrams longer than 4096 bytes are discarded with an error message.
+port "FILE": args is the file name.
+port "STDIN"|"FIFO:path": args are ignored.
+port "CMD": args is the command line. Use quotes around parts containing spaces.
+port "OPENOCD:host:port": args are ignored, the RTT channel is selected with the OpenOCD "rtt server start" command.
+port "JLINKRTT[:host[:port]]": args is the RTT channel number, "default" is channel 0.
 `)
 
 	fsScLog.StringVar(&receiver.PortArguments, "args", "default", argsInfo)
+	fsScLog.BoolVar(&receiver.Follow, "follow", false, `Keep reading port "FILE" at its end like "tail -f" for still growing files. Without it the log ends at the file end. `+boolInfo)
 	fsScLog.BoolVar(&emitter.DisplayRemote, "displayserver", false, `Send trice lines to displayserver @ ipa:ipp.
 Example: "trice l -port COM38 -ds -ipa 192.168.178.44" sends trice output to a previously started display server in the same network.`)
 	fsScLog.BoolVar(&emitter.DisplayRemote, "ds", false, "Short for '-displayserver'.")
@@ -117,10 +157,48 @@ Example: "trice l -port COM38 -displayserver -autostart" opens a separate displa
 `+boolInfo)
 	fsScLog.BoolVar(&receiver.ShowInputBytes, "s", false, "Short for '-showInputBytes'.")
 	fsScLog.BoolVar(&decoder.TestTableMode, "testTable", false, `Generate testTable output and ignore -prefix, -suffix, -ts, -color. `+boolInfo)
+	fsScLog.StringVar(&receiver.RawLog, "rawlog", "", `Record the undecoded input bytes with their arrival times into a raw log file like "capture.trb".
+Use "trice replay capture.trb" later to decode them again, for example with a corrected til.json or a different -encoding. Default is "" for no recording.
+`) // flag
+	fsScLog.BoolVar(&keybcmd.Keyboard, "keybcmd", false, `Read command lines from the keyboard (stdin) during logging and send them to the target, for example to use its command shell.
+This works for serial ports and "TCP4:host:port" connections. With -displayserver the lines typed in the display server window are sent too,
+when the display server was started with -autostart or with "trice ds -keybcmd".
+Lines starting with "!" are local commands, type "!help" for a list. Not usable together with port "STDIN".
+`+boolInfo) // flag
+	fsScLog.StringVar(&keybcmd.CmdFile, "cmdfile", "", `Send the command lines in this file to the target after each connect. A still running command file ends with the connection. Empty lines and lines starting with "#" are ignored.
+Local commands like "!sleep 100ms" are possible. Default is "" for no command file.
+`) // flag
+	fsScLog.StringVar(&keybcmd.EOL, "cmdeol", "CRLF", "Line ending appended to each command line sent to the target, options: 'CRLF|CR|LF|none'.") // flag
 	flagLogfile(fsScLog)
 	flagVerbosity(fsScLog)
 	flagIDList(fsScLog)
 	flagIPAddress(fsScLog)
+	flagStats(fsScLog)
+}
+
+func init() {
+	fsScReplay = flag.NewFlagSet("replay", flag.ExitOnError)                                              // subcommand
+	fsScReplay.StringVar(&decoder.Encoding, "encoding", "flexL", encodingInfo(replayEncodingInfo))        // flag
+	fsScReplay.StringVar(&decoder.Encoding, "e", "flexL", "Short for -encoding.")                         // short flag
+	fsScReplay.StringVar(&cipher.Password, "password", "", "The decrypt passphrase, see 'trice h -log'.") // flag
+	fsScReplay.StringVar(&cipher.Password, "pw", "", "Short for -password.")                              // short flag
+	fsScReplay.StringVar(&receiver.ReplaySpeed, "speed", "1x", `Replay speed, options: '1x|10x|max':
+"1x" replays with the recorded timing, "10x" 10 times faster and "max" without any waiting. Any factor like "0.5x" is possible.
+`) // flag
+	fsScReplay.StringVar(&emitter.TimestampFormat, "ts", "LOCmicro", "PC timestamp for logs, options: 'off|none|UTCmicro|zero'. The timestamps are the replay times.") // flag
+	fsScReplay.StringVar(&decoder.ShowID, "showID", "", `Format string for displaying first trice ID at start of each line. Example: "debug:%7d ".`)
+	fsScReplay.StringVar(&emitter.ColorPalette, "color", "default", colorInfo)                              // flag
+	fsScReplay.StringVar(&emitter.Prefix, "prefix", DefaultPrefix, "Line prefix, see 'trice h -log'.")      // flag
+	fsScReplay.StringVar(&emitter.Suffix, "suffix", "", "Append suffix to all lines, options: any string.") // flag
+	fsScReplay.BoolVar(&decoder.UnsignedHex, "unsignedHex", false, "Hex and Bin values are printed as unsigned values.")
+	fsScReplay.BoolVar(&decoder.UnsignedHex, "u", false, "Short for '-unsignedHex'.")
+	fsScReplay.BoolVar(&emitter.DisplayRemote, "displayserver", false, "Send trice lines to displayserver @ ipa:ipp.")
+	fsScReplay.BoolVar(&emitter.DisplayRemote, "ds", false, "Short for '-displayserver'.")
+	flagLogfile(fsScReplay)
+	flagVerbosity(fsScReplay)
+	flagIDList(fsScReplay)
+	flagIPAddress(fsScReplay)
+	flagStats(fsScReplay)
 }
 
 func init() {
@@ -162,12 +240,15 @@ func init() {
 func init() {
 	fsScSv = flag.NewFlagSet("displayServer", flag.ExitOnError)            // subcommand
 	fsScSv.StringVar(&emitter.ColorPalette, "color", "default", colorInfo) // flag
+	fsScSv.BoolVar(&emitter.ServerKeyboard, "keybcmd", false, `Read command lines from the keyboard (stdin) for a "trice log -keybcmd -displayserver" instance, which sends them to the target.
+`+boolInfo) // flag
 	flagLogfile(fsScSv)
 	flagIPAddress(fsScSv)
 }
 
 func init() {
 	fsScScan = flag.NewFlagSet("scan", flag.ContinueOnError) // subcommand
+	fsScScan.BoolVar(&com.ScanJSON, "json", false, "Print the serial port list with USB data as JSON. "+boolInfo)
 }
 
 func init() {
@@ -230,6 +311,14 @@ The specified JSON file is needed to display the ID coded trices during runtime
 `) // flag
 }
 
+func flagStats(p *flag.FlagSet) {
+	p.BoolVar(&decoder.Stats, "stats", false, `Report decoder statistics as "inf:" lines at exit: decoded trices per ID and channel, discarded bytes,
+decoder errors per reason, lost trices estimated from cycle counter gaps, unknown IDs, sync packets, re-syncs and serial line errors.
+`+boolInfo) // flag
+	p.DurationVar(&decoder.StatsInterval, "statsinterval", 0, `Report the -stats statistics also periodically during logging, example: "-statsinterval 1m". 0 is for only at exit.
+`) // flag
+}
+
 func flagIPAddress(p *flag.FlagSet) {
 	p.StringVar(&emitter.IPAddr, "ipa", "localhost", `IP address like '127.0.0.1'.
 You can specify this swich if you intend to use the remote display option to show the output on a different PC in the network.
@@ -239,3 +328,52 @@ You can specify this swich if you intend to use the remote display option to sho
 You can specify this swich if you want to change the used port number for the remote display functionality.
 `) // flag
 }
+
+const (
+	logEncodingInfo    = "Target device encoding must match."
+	replayEncodingInfo = "Must match the recorded encoding."
+)
+
+// encodingInfo returns the -encoding help text for the registered encodings, ending with end.
+func encodingInfo(end string) string {
+	return fmt.Sprintf("The trice transmit data format type, options: '%s'. Names are case insensitive. %s\n%s\n",
+		strings.Join(decoder.EncodingNames(), "|"), end, decoder.EncodingInfo())
+}
+
+// updateEncodingInfo renews the -encoding help texts, because encodings can be registered after the flag definitions.
+func updateEncodingInfo() {
+	fsScLog.Lookup("encoding").Usage = encodingInfo(logEncodingInfo)
+	fsScReplay.Lookup("encoding").Usage = encodingInfo(replayEncodingInfo)
+}
+
+// baudValue is the flag.Value for the serial port baud rate. It accepts a number or "auto".
+type baudValue struct {
+	baud *int
+	auto *bool
+}
+
+// String implements part of flag.Value interface. It returns the baud rate as string.
+func (p baudValue) String() string {
+	if nil == p.baud {
+		return "" // zero value
+	}
+	if *p.auto {
+		return "auto"
+	}
+	return strconv.Itoa(*p.baud)
+}
+
+// Set implements part of flag.Value interface. It sets the baud rate or the auto detection according to s.
+func (p baudValue) Set(s string) error {
+	if strings.EqualFold("auto", s) {
+		*p.auto = true
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if nil != err || n <= 0 {
+		return fmt.Errorf("expecting a number or auto")
+	}
+	*p.auto = false
+	*p.baud = n
+	return nil
+}
@@ -7,6 +7,7 @@ package args
 
 import (
 	"flag"
+	"time"
 )
 
 var (
@@ -22,6 +23,16 @@ var (
 	// verbose gives mor information on output if set. This variable is copied into the appropriate packages.
 	verbose bool
 
+	// minRetryInterval is the first wait time after a failed re-connect. It is doubled after each failed try up to maxRetryInterval.
+	minRetryInterval = 250 * time.Millisecond
+
+	// maxRetryInterval is the longest wait time between re-connect tries.
+	maxRetryInterval = 4 * time.Second
+
+	// minConnectedTime is the connection duration, after which a lost connection counts as working one, even without received data.
+	// A lost working connection is re-connected after minRetryInterval, otherwise the wait time is doubled like after a failed re-connect.
+	minConnectedTime = 10 * time.Second
+
 	// used to replace "default" args value for STLINK and JLINK port
 	defaultLinkArgs = "-Device STM32F030R8 -if SWD -Speed 4000 -RTTChannel 0 -RTTSearchRanges 0x20000000_0x1000"
 
@@ -46,6 +57,9 @@ var (
 	// fsScLog is flag set for sub command 'log'.
 	fsScLog *flag.FlagSet
 
+	// fsScReplay is flag set for sub command 'replay'.
+	fsScReplay *flag.FlagSet
+
 	// fsScSv is flag set for sub command 'displayServer'.
 	fsScSv *flag.FlagSet
 
@@ -70,6 +84,7 @@ var (
 	logHelp           bool // flag for partial help
 	refreshHelp       bool // flag for partial help
 	renewHelp         bool // flag for partial help
+	replayHelp        bool // flag for partial help
 	scanHelp          bool // flag for partial help
 	shutdownHelp      bool // flag for partial help
 	updateHelp        bool // flag for partial help
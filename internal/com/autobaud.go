@@ -0,0 +1,19 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package com
+
+import (
+	"time"
+)
+
+var (
+	// AutoBaud is true if the baud rate is to be detected from the received data. It is set with command line parameter "-baud auto".
+	AutoBaud bool
+
+	// AutoBaudRates are the baud rates tried in this order, when AutoBaud is true.
+	AutoBaudRates = []int{115200, 921600, 460800, 230400, 57600, 38400, 19200, 9600, 1000000, 2000000}
+
+	// AutoBaudTime is the receive duration for each tried baud rate.
+	AutoBaudTime = time.Second
+)
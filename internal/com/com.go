@@ -6,11 +6,16 @@
 package com
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 	"time"
 
 	serialtarm "github.com/tarm/serial"
 	serialgobugst "go.bug.st/serial"
+	"go.bug.st/serial/enumerator"
 )
 
 var (
@@ -19,13 +24,18 @@ var (
 
 	// Verbose shows additional information if set true.
 	Verbose bool
+
+	// errHangUp is returned when a serial port delivers no data anymore, for example after unplugging an USB serial adapter.
+	errHangUp = errors.New("serial port hung up")
 )
 
 // COMport is the comport interface type to use different COMports.
 type COMport interface {
 	Open() bool
 	Read(buf []byte) (int, error)
+	Write(buf []byte) (int, error)
 	Close() error
+	Signaler
 }
 
 // PortGoBugSt is a serial device trice receiver
@@ -33,18 +43,17 @@ type PortGoBugSt struct {
 	port         string
 	serialHandle serialgobugst.Port
 	serialMode   serialgobugst.Mode
+	rtscts       bool
+	ctl          int // control file descriptor, see openWithControl
 }
 
-// NewCOMPortGoBugSt creates an instance of a serial device type trice receiver
-func NewCOMPortGoBugSt(comPortName string) *PortGoBugSt {
+// NewCOMPortGoBugSt creates an instance of a serial device type trice receiver with the line configuration l.
+func NewCOMPortGoBugSt(comPortName string, l Line) *PortGoBugSt {
 	r := &PortGoBugSt{
-		port: comPortName,
-		serialMode: serialgobugst.Mode{
-			BaudRate: Baud,
-			DataBits: 8,
-			Parity:   serialgobugst.NoParity,
-			StopBits: serialgobugst.OneStopBit,
-		},
+		port:       comPortName,
+		serialMode: gobugstMode(l),
+		rtscts:     l.RTSCTS,
+		ctl:        -1,
 	}
 	if Verbose {
 		fmt.Println("NewCOMPortGoBugSt:", r)
@@ -56,8 +65,18 @@ func NewCOMPortGoBugSt(comPortName string) *PortGoBugSt {
 // the serial port or an error occurs.
 // It stores data received from the serial port into the provided byte array
 // buffer. The function returns the number of bytes read.
+// A read without data and without error happens only after a hang up and is reported as error.
 func (p *PortGoBugSt) Read(buf []byte) (int, error) {
-	return p.serialHandle.Read(buf)
+	n, err := p.serialHandle.Read(buf)
+	if 0 == n && nil == err {
+		return 0, errHangUp
+	}
+	return n, err
+}
+
+// Write sends buf over the serial port. It can be called while a Read is blocking.
+func (p *PortGoBugSt) Write(buf []byte) (int, error) {
+	return p.serialHandle.Write(buf)
 }
 
 // Close releases port.
@@ -65,16 +84,41 @@ func (p *PortGoBugSt) Close() error {
 	if Verbose {
 		fmt.Println("Closing GoBugSt COM port")
 	}
+	defer closeControl(&p.ctl)
 	return p.serialHandle.Close()
 }
 
+// SetDTR asserts (on true) or releases the DTR line.
+func (p *PortGoBugSt) SetDTR(on bool) error {
+	return p.serialHandle.SetDTR(on)
+}
+
+// SetRTS asserts (on true) or releases the RTS line.
+func (p *PortGoBugSt) SetRTS(on bool) error {
+	return p.serialHandle.SetRTS(on)
+}
+
+// Break holds the serial line in break condition for duration d.
+func (p *PortGoBugSt) Break(d time.Duration) error {
+	return sendBreak(p.ctl, d)
+}
+
 // Open initializes the serial receiver.
 //
 // It opens a serial port.
 func (p *PortGoBugSt) Open() bool {
+	var opened bool
+	open := func() (err error) {
+		p.serialHandle, err = serialgobugst.Open(p.port, &p.serialMode)
+		opened = nil == err
+		return
+	}
 	var err error
-	p.serialHandle, err = serialgobugst.Open(p.port, &p.serialMode)
+	p.ctl, err = openWithControl(p.port, p.rtscts, open)
 	if err != nil {
+		if opened { // flow control setting failed
+			_ = p.serialHandle.Close()
+		}
 		if Verbose {
 			fmt.Println(err, "try 'trice s' to check for serial ports")
 		}
@@ -105,15 +149,18 @@ func GetSerialPorts() ([]string, error) {
 type PortTarm struct {
 	config serialtarm.Config
 	stream *serialtarm.Port
+	rtscts bool
+	ctl    int // control file descriptor, see openWithControl
 }
 
-// NewCOMPortTarm creates an instance of a serial device type trice receiver.
-func NewCOMPortTarm(comPortName string) *PortTarm {
+// NewCOMPortTarm creates an instance of a serial device type trice receiver with the line configuration l.
+func NewCOMPortTarm(comPortName string, l Line) *PortTarm {
 	var p = new(PortTarm)
+	p.ctl = -1
+	p.rtscts = l.RTSCTS
 	p.config.Name = comPortName
-	p.config.Baud = Baud
 	p.config.ReadTimeout = 100 * time.Millisecond
-	p.config.Size = 8
+	tarmConfig(&p.config, l)
 	if Verbose {
 		fmt.Println("NewCOMPortTarm:", p.config)
 	}
@@ -122,10 +169,20 @@ func NewCOMPortTarm(comPortName string) *PortTarm {
 
 // Open returns true on successful operation.
 func (p *PortTarm) Open() bool {
+	var opened bool
+	open := func() (err error) {
+		p.stream, err = serialtarm.OpenPort(&p.config)
+		opened = nil == err
+		return
+	}
 	var err error
-	p.stream, err = serialtarm.OpenPort(&p.config)
+	p.ctl, err = openWithControl(p.config.Name, p.rtscts, open)
 	if err != nil {
+		if opened { // flow control setting failed
+			_ = p.stream.Close()
+		}
 		if Verbose {
+			fmt.Println(err)
 			fmt.Println(p.config.Name, "not found")
 			fmt.Println("try 'trice scan'")
 		}
@@ -139,13 +196,86 @@ func (p *PortTarm) Close() error {
 	if Verbose {
 		fmt.Println("Closing Tarm COM port")
 	}
+	defer closeControl(&p.ctl)
 	return p.stream.Close()
 }
 
+// SetDTR asserts (on true) or releases the DTR line. The tarm driver has no line control, so this works only on Linux.
+func (p *PortTarm) SetDTR(on bool) error {
+	return setModemLine(p.ctl, lineDTR, on)
+}
+
+// SetRTS asserts (on true) or releases the RTS line. The tarm driver has no line control, so this works only on Linux.
+func (p *PortTarm) SetRTS(on bool) error {
+	return setModemLine(p.ctl, lineRTS, on)
+}
+
+// Break holds the serial line in break condition for duration d.
+func (p *PortTarm) Break(d time.Duration) error {
+	return sendBreak(p.ctl, d)
+}
+
 // Read blocks until (at least) one byte is received from
 // the serial port or an error occurs.
 // It stores data received from the serial port into the provided byte array
 // buffer. The function returns the number of bytes read.
+// A read timeout returns io.EOF. If the port disappeared meanwhile an error is returned instead.
 func (p *PortTarm) Read(buf []byte) (int, error) {
-	return p.stream.Read(buf)
+	n, err := p.stream.Read(buf)
+	if io.EOF == err && !Exists(p.config.Name) {
+		return n, errHangUp
+	}
+	return n, err
+}
+
+// Write sends buf over the serial port. It can be called while a Read is waiting.
+func (p *PortTarm) Write(buf []byte) (int, error) {
+	return p.stream.Write(buf)
+}
+
+// Exists returns true if the serial port name is present.
+// If this cannot be determined, Exists returns true and leaves the decision to a port open.
+func Exists(name string) bool {
+	if strings.HasPrefix(name, "/") { // device node
+		_, err := os.Stat(name)
+		return nil == err
+	}
+	ports, err := serialgobugst.GetPortsList()
+	if nil != err {
+		return true
+	}
+	for _, port := range ports {
+		if strings.EqualFold(port, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// USBSerialNumber returns the USB serial number of the serial port name or "" if it has none.
+func USBSerialNumber(name string) string {
+	ports, err := enumerator.GetDetailedPortsList()
+	if nil != err {
+		return ""
+	}
+	for _, port := range ports {
+		if port.IsUSB && strings.EqualFold(port.Name, name) {
+			return port.SerialNumber
+		}
+	}
+	return ""
+}
+
+// PortWithUSBSerialNumber returns the name of the serial port with USB serial number sn or "" if there is none.
+func PortWithUSBSerialNumber(sn string) string {
+	ports, err := enumerator.GetDetailedPortsList()
+	if nil != err || "" == sn {
+		return ""
+	}
+	for _, port := range ports {
+		if port.IsUSB && sn == port.SerialNumber {
+			return port.Name
+		}
+	}
+	return ""
 }
@@ -6,9 +6,12 @@
 package com_test
 
 import (
+	"encoding/json"
+	"runtime"
 	"testing"
 
 	"github.com/rokath/trice/internal/com"
+	"github.com/rokath/trice/pkg/tst"
 	"github.com/tj/assert"
 )
 
@@ -28,9 +31,110 @@ func Test1(t *testing.T) {
 
 	for i := range ss {
 		port := ss[i]
-		p := com.NewCOMPortGoBugSt(port)
+		p := com.NewCOMPortGoBugSt(port, defaultLine(t))
 		if p.Open() {
 			assert.Nil(t, p.Close())
 		}
 	}
 }
+
+// resetLine restores the default line configuration.
+func resetLine() {
+	com.Baud, com.DataBits, com.Parity, com.StopBits, com.RTSCTS, com.Reset = 115200, 8, "N", "1", false, ""
+}
+
+// defaultLine returns the default line configuration.
+func defaultLine(t *testing.T) com.Line {
+	resetLine()
+	l, err := com.ParseArgs("")
+	assert.Nil(t, err)
+	return l
+}
+
+func TestParseArgs(t *testing.T) {
+	defer resetLine()
+	tt := []struct {
+		args string
+		tarm bool
+		exp  string
+	}{
+		{"default", false, "115200,8,N,1"},
+		{"", false, "115200,8,N,1"},
+		{"TARM", true, "115200,8,N,1"},
+		{"TARM, 9600", true, "9600,8,N,1"},
+		{"57600,7,odd,2", false, "57600,7,O,2"},
+		{"19200,8,none,1.5", false, "19200,8,N,1.5"},
+		{"space", false, "115200,8,S,1"},
+	}
+	for _, x := range tt {
+		resetLine()
+		l, err := com.ParseArgs(x.args)
+		assert.Nil(t, err, x.args)
+		assert.Equal(t, x.tarm, l.Tarm, x.args)
+		assert.Equal(t, x.exp, l.String(), x.args)
+		assert.Equal(t, "115200,8,N,1", defaultLine(t).String(), x.args) // the command line parameters are unchanged
+	}
+	l, err := com.ParseArgs("115200,8,E,1,rtscts")
+	if "linux" == runtime.GOOS {
+		assert.Nil(t, err)
+		assert.Equal(t, "115200,8,E,1,rtscts", l.String())
+	} else { // RTS/CTS flow control is supported only on Linux
+		assert.NotNil(t, err)
+	}
+	for _, args := range []string{"fast", "115200,9", "115200,8,N,3", "115200,8,N,1,2", "0"} {
+		resetLine()
+		_, err := com.ParseArgs(args)
+		assert.NotNil(t, err, args)
+	}
+	if "windows" != runtime.GOOS { // the tarm driver supports these settings only on Windows
+		for _, args := range []string{"TARM,mark", "TARM,115200,8,S,1", "TARM,19200,8,N,1.5"} {
+			resetLine()
+			_, err := com.ParseArgs(args)
+			assert.NotNil(t, err, args)
+		}
+	}
+	resetLine()
+	com.Parity = "X" // invalid flag value
+	_, err = com.ParseArgs("default")
+	assert.NotNil(t, err)
+}
+
+func TestParseArgsAutoBaud(t *testing.T) {
+	defer resetLine()
+	defer func() { com.AutoBaud = false }()
+	resetLine()
+	com.AutoBaud = true
+	com.Baud = 921600 // a tried rate
+	l, err := com.ParseArgs("115200,7,E,2")
+	assert.Nil(t, err)
+	assert.Equal(t, "921600,7,E,2", l.String())
+}
+
+func TestPortInfoString(t *testing.T) {
+	p := com.PortInfo{Name: "/dev/ttyS0"}
+	assert.Equal(t, "/dev/ttyS0", p.String())
+	p = com.PortInfo{Name: "/dev/ttyUSB0", IsUSB: true, VID: "0403", PID: "6001", SerialNumber: "A50285BI", Manufacturer: "FTDI", Product: "FT232R USB UART"}
+	assert.Equal(t, "/dev/ttyUSB0 USB VID:PID=0403:6001 SN=A50285BI FTDI FT232R USB UART", p.String())
+}
+
+func TestScanJSON(t *testing.T) {
+	com.ScanJSON = true
+	defer func() { com.ScanJSON = false }()
+	act := tst.CaptureStdOut(func() { assert.Nil(t, com.Scan()) })
+	var infos []com.PortInfo
+	assert.Nil(t, json.Unmarshal([]byte(act), &infos))
+	exp, err := com.GetPortInfos()
+	assert.Nil(t, err)
+	assert.Equal(t, exp, infos)
+}
+
+func TestFindUSBPort(t *testing.T) {
+	assert.True(t, com.IsUSBPort("USB:0403:6001"))
+	assert.False(t, com.IsUSBPort("/dev/ttyUSB0"))
+	for _, port := range []string{"usb:", "usb:0403", "usb::6001"} {
+		_, err := com.FindUSBPort(port)
+		assert.NotNil(t, err, port)
+	}
+	_, err := com.FindUSBPort("usb:ffff:ffff:notExisting")
+	assert.NotNil(t, err)
+}
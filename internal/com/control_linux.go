@@ -0,0 +1,108 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package com
+
+import (
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// openWithControl calls open for the serial port name and returns a control file descriptor for the port, or -1 if not available.
+// The serial drivers take exclusive port access, so the control file descriptor is opened in front.
+// It is used for switching on RTS/CTS hardware flow control if rtscts is true, which the serial drivers clear on open,
+// and for the modem control lines and the break signal. The caller needs to close it together with the port.
+func openWithControl(name string, rtscts bool, open func() error) (ctl int, err error) {
+	ctl, err = unix.Open(name, unix.O_RDWR|unix.O_NOCTTY|unix.O_NONBLOCK, 0)
+	if nil != err {
+		if rtscts {
+			return -1, err
+		}
+		return -1, open() // works without control
+	}
+	if err = open(); nil != err {
+		_ = unix.Close(ctl)
+		return -1, err
+	}
+	if rtscts {
+		if err = setRTSCTS(ctl); nil != err {
+			_ = unix.Close(ctl)
+			return -1, err
+		}
+	}
+	return
+}
+
+// setRTSCTS switches on RTS/CTS hardware flow control for the serial port with file descriptor fd.
+func setRTSCTS(fd int) error {
+	t, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if nil != err {
+		return err
+	}
+	t.Cflag |= unix.CRTSCTS
+	return unix.IoctlSetTermios(fd, unix.TCSETS, t)
+}
+
+// closeControl closes the control file descriptor *ctl, if valid, and invalidates it.
+func closeControl(ctl *int) {
+	if 0 <= *ctl {
+		_ = unix.Close(*ctl)
+	}
+	*ctl = -1
+}
+
+// setModemLine asserts (on true) or releases the modem control lines in bits, like unix.TIOCM_DTR, using ctl.
+func setModemLine(ctl int, bits int, on bool) error {
+	if ctl < 0 {
+		return errNoControl
+	}
+	req := uint(unix.TIOCMBIC)
+	if on {
+		req = unix.TIOCMBIS
+	}
+	return unix.IoctlSetPointerInt(ctl, req, bits)
+}
+
+// sendBreak holds the serial line in break condition for duration d using ctl.
+func sendBreak(ctl int, d time.Duration) error {
+	if ctl < 0 {
+		return errNoControl
+	}
+	if err := unix.IoctlSetInt(ctl, unix.TIOCSBRK, 0); nil != err {
+		return err
+	}
+	time.Sleep(d)
+	return unix.IoctlSetInt(ctl, unix.TIOCCBRK, 0)
+}
+
+const (
+	lineDTR = unix.TIOCM_DTR
+	lineRTS = unix.TIOCM_RTS
+)
+
+// serialIcounter is the Linux struct serial_icounter_struct.
+type serialIcounter struct {
+	cts, dsr, rng, dcd int32
+	rx, tx             int32
+	frame, overrun     int32
+	parity, brk        int32
+	bufOverrun         int32
+	reserved           [9]int32
+}
+
+// lineErrors reads the serial line error counters using ctl.
+func lineErrors(ctl int) (LineErrors, error) {
+	if ctl < 0 {
+		return LineErrors{}, errNoControl
+	}
+	var c serialIcounter
+	if _, _, e := unix.Syscall(unix.SYS_IOCTL, uintptr(ctl), unix.TIOCGICOUNT, uintptr(unsafe.Pointer(&c))); 0 != e {
+		return LineErrors{}, e
+	}
+	return LineErrors{Frame: int(c.frame), Parity: int(c.parity), Overrun: int(c.overrun), BufOverrun: int(c.bufOverrun), Break: int(c.brk)}, nil
+}
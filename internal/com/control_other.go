@@ -0,0 +1,44 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package com
+
+import (
+	"errors"
+	"time"
+)
+
+// openWithControl calls open for the serial port name. A control file descriptor is not available on this OS, so it returns -1.
+// RTS/CTS hardware flow control is not supported by the serial drivers on this OS.
+func openWithControl(name string, rtscts bool, open func() error) (ctl int, err error) {
+	if rtscts {
+		return -1, errors.New("RTS/CTS flow control is supported only on Linux")
+	}
+	return -1, open()
+}
+
+// closeControl does nothing on this OS.
+func closeControl(ctl *int) {}
+
+// setModemLine returns an error, because there is no control file descriptor on this OS.
+func setModemLine(ctl int, bits int, on bool) error {
+	return errNoControl
+}
+
+// sendBreak returns an error, because there is no control file descriptor on this OS.
+func sendBreak(ctl int, d time.Duration) error {
+	return errNoControl
+}
+
+const (
+	lineDTR = 1
+	lineRTS = 2
+)
+
+// lineErrors returns an error, because there is no control file descriptor on this OS.
+func lineErrors(ctl int) (LineErrors, error) {
+	return LineErrors{}, errNoControl
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package com_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/rokath/trice/internal/com"
+	"github.com/tj/assert"
+	"golang.org/x/sys/unix"
+)
+
+// testHangUp closes the pseudo terminal master like an unplugged USB serial adapter and expects a read error.
+func testHangUp(t *testing.T, newPort func(name string, l com.Line) com.COMport) {
+	fd, name := openPty(t)
+	p := newPort(name, defaultLine(t))
+	assert.True(t, p.Open())
+	_, err := unix.Write(fd, []byte{1, 2, 3})
+	assert.Nil(t, err)
+	b := make([]byte, 100)
+	n, err := io.ReadAtLeast(p, b, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, b[:n])
+	assert.True(t, com.Exists(name))
+
+	assert.Nil(t, unix.Close(fd))
+	for nil == err || io.EOF == err { // tarm reports read timeouts as io.EOF
+		_, err = p.Read(b)
+	}
+	assert.NotNil(t, err)
+	assert.False(t, com.Exists(name))
+	_ = p.Close()
+}
+
+func TestHangUpGoBugSt(t *testing.T) {
+	testHangUp(t, func(name string, l com.Line) com.COMport { return com.NewCOMPortGoBugSt(name, l) })
+}
+
+func TestHangUpTarm(t *testing.T) {
+	testHangUp(t, func(name string, l com.Line) com.COMport { return com.NewCOMPortTarm(name, l) })
+}
@@ -0,0 +1,180 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package com
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+
+	serialtarm "github.com/tarm/serial"
+	serialgobugst "go.bug.st/serial"
+)
+
+var (
+	// DataBits is the configured data bits count of the serial port, 5...8. It is set as command line parameter.
+	DataBits = 8
+
+	// Parity is the configured parity of the serial port, "N", "E", "O", "M" or "S". It is set as command line parameter.
+	Parity = "N"
+
+	// StopBits is the configured stop bits count of the serial port, "1", "1.5" or "2". It is set as command line parameter.
+	StopBits = "1"
+
+	// RTSCTS switches on hardware flow control if set true. It is set as command line parameter.
+	RTSCTS bool
+)
+
+// parities maps the accepted parity names to the parity letter.
+var parities = map[string]string{
+	"N": "N", "NONE": "N",
+	"E": "E", "EVEN": "E",
+	"O": "O", "ODD": "O",
+	"M": "M", "MARK": "M",
+	"S": "S", "SPACE": "S",
+}
+
+// Line is a serial line configuration.
+type Line struct {
+	Baud     int    // baud rate
+	DataBits int    // data bits count, 5...8
+	Parity   string // parity letter "N", "E", "O", "M" or "S"
+	StopBits string // stop bits count "1", "1.5" or "2"
+	RTSCTS   bool   // RTS/CTS hardware flow control
+	Tarm     bool   // use the tarm driver
+}
+
+// ParseArgs evaluates the serial port args string like "115200,8,E,1,rtscts" and returns the line configuration.
+// The numbers are in order baud rate, data bits and stop bits. Parity and the keywords "rtscts" and "TARM" can be anywhere.
+// Omitted values are taken from the command line parameters, so "default" or "" only checks them.
+// "TARM" selects the tarm driver.
+// With AutoBaud a baud rate in args is ignored, so the baud rate detection can try its rates with the other line settings.
+func ParseArgs(args string) (l Line, err error) {
+	l = Line{Baud: Baud, DataBits: DataBits, Parity: Parity, StopBits: StopBits, RTSCTS: RTSCTS}
+	if "default" == args {
+		args = ""
+	}
+	var numbers int
+	for _, s := range strings.Split(args, ",") {
+		s = strings.TrimSpace(s)
+		if p, ok := parities[strings.ToUpper(s)]; ok {
+			l.Parity = p
+			continue
+		}
+		switch strings.ToUpper(s) {
+		case "":
+			continue
+		case "TARM":
+			l.Tarm = true
+			continue
+		case "RTSCTS":
+			l.RTSCTS = true
+			continue
+		}
+		switch numbers {
+		case 0:
+			l.Baud, err = strconv.Atoi(s)
+		case 1:
+			l.DataBits, err = strconv.Atoi(s)
+		case 2:
+			l.StopBits = s
+		default:
+			err = fmt.Errorf("too many values")
+		}
+		if nil != err {
+			return l, fmt.Errorf("invalid serial port args %s at %s: %v", args, s, err)
+		}
+		numbers++
+	}
+	if AutoBaud { // the tried or detected baud rate wins
+		l.Baud = Baud
+	}
+	if p, ok := parities[strings.ToUpper(l.Parity)]; ok {
+		l.Parity = p
+	}
+	return l, l.check()
+}
+
+// check returns an error if the line configuration is not valid.
+// RTS/CTS flow control is switched on with the Linux termios, so it is rejected on other OSs.
+// With Tarm it rejects also the settings the tarm driver does not support outside Windows: mark and space parity and 1.5 stop bits.
+func (l Line) check() error {
+	if l.Baud <= 0 {
+		return fmt.Errorf("invalid baud rate %d", l.Baud)
+	}
+	if l.DataBits < 5 || 8 < l.DataBits {
+		return fmt.Errorf("invalid data bits %d, expecting 5...8", l.DataBits)
+	}
+	if _, ok := parities[strings.ToUpper(l.Parity)]; !ok {
+		return fmt.Errorf("invalid parity %s, expecting N|E|O|M|S", l.Parity)
+	}
+	switch l.StopBits {
+	case "1", "1.5", "2":
+	default:
+		return fmt.Errorf("invalid stop bits %s, expecting 1|1.5|2", l.StopBits)
+	}
+	if l.RTSCTS && "linux" != runtime.GOOS {
+		return fmt.Errorf("RTS/CTS flow control is supported only on Linux")
+	}
+	if l.Tarm && "windows" != runtime.GOOS {
+		if "M" == l.Parity || "S" == l.Parity {
+			return fmt.Errorf("parity %s is not supported by the TARM driver on %s, expecting N|E|O", l.Parity, runtime.GOOS)
+		}
+		if "1.5" == l.StopBits {
+			return fmt.Errorf("stop bits 1.5 are not supported by the TARM driver on %s, expecting 1|2", runtime.GOOS)
+		}
+	}
+	return nil
+}
+
+// String returns the line configuration like "115200,8,E,1,rtscts".
+func (l Line) String() string {
+	s := fmt.Sprintf("%d,%d,%s,%s", l.Baud, l.DataBits, l.Parity, l.StopBits)
+	if l.RTSCTS {
+		s += ",rtscts"
+	}
+	return s
+}
+
+// gobugstMode returns the line configuration l for the go.bug.st driver.
+func gobugstMode(l Line) serialgobugst.Mode {
+	m := serialgobugst.Mode{BaudRate: l.Baud, DataBits: l.DataBits}
+	switch l.Parity {
+	case "E":
+		m.Parity = serialgobugst.EvenParity
+	case "O":
+		m.Parity = serialgobugst.OddParity
+	case "M":
+		m.Parity = serialgobugst.MarkParity
+	case "S":
+		m.Parity = serialgobugst.SpaceParity
+	default:
+		m.Parity = serialgobugst.NoParity
+	}
+	switch l.StopBits {
+	case "1.5":
+		m.StopBits = serialgobugst.OnePointFiveStopBits
+	case "2":
+		m.StopBits = serialgobugst.TwoStopBits
+	default:
+		m.StopBits = serialgobugst.OneStopBit
+	}
+	return m
+}
+
+// tarmConfig sets the line configuration l for the tarm driver in c.
+func tarmConfig(c *serialtarm.Config, l Line) {
+	c.Baud = l.Baud
+	c.Size = byte(l.DataBits)
+	c.Parity = serialtarm.Parity(l.Parity[0])
+	switch l.StopBits {
+	case "1.5":
+		c.StopBits = serialtarm.Stop1Half
+	case "2":
+		c.StopBits = serialtarm.Stop2
+	default:
+		c.StopBits = serialtarm.Stop1
+	}
+}
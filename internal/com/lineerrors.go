@@ -0,0 +1,65 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package com
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LineErrors are the serial line error counters of the serial driver.
+type LineErrors struct {
+	Frame      int // framing errors, often a wrong baud rate or a disturbed line
+	Parity     int // parity errors
+	Overrun    int // UART receive overruns, the bytes were not fetched in time
+	BufOverrun int // driver buffer overruns
+	Break      int // received break conditions
+}
+
+// Sub returns the counter increments from q to p.
+func (p LineErrors) Sub(q LineErrors) LineErrors {
+	return LineErrors{p.Frame - q.Frame, p.Parity - q.Parity, p.Overrun - q.Overrun, p.BufOverrun - q.BufOverrun, p.Break - q.Break}
+}
+
+// Add returns the sum of the counters in p and q.
+func (p LineErrors) Add(q LineErrors) LineErrors {
+	return LineErrors{p.Frame + q.Frame, p.Parity + q.Parity, p.Overrun + q.Overrun, p.BufOverrun + q.BufOverrun, p.Break + q.Break}
+}
+
+// Total returns the sum of all counters.
+func (p LineErrors) Total() int {
+	return p.Frame + p.Parity + p.Overrun + p.BufOverrun + p.Break
+}
+
+// String returns the not zero counters like "2 framing, 1 overrun".
+func (p LineErrors) String() string {
+	var s []string
+	for _, x := range []struct {
+		n    int
+		name string
+	}{{p.Frame, "framing"}, {p.Parity, "parity"}, {p.Overrun, "overrun"}, {p.BufOverrun, "buffer overrun"}, {p.Break, "break"}} {
+		if 0 != x.n {
+			s = append(s, fmt.Sprint(x.n, " ", x.name))
+		}
+	}
+	if 0 == len(s) {
+		return "none"
+	}
+	return strings.Join(s, ", ")
+}
+
+// LineErrorCounter is implemented by serial ports able to deliver the serial line error counters.
+type LineErrorCounter interface {
+	LineErrors() (LineErrors, error)
+}
+
+// LineErrors returns the serial line error counters. This works only on Linux and with serial drivers supporting it.
+func (p *PortGoBugSt) LineErrors() (LineErrors, error) {
+	return lineErrors(p.ctl)
+}
+
+// LineErrors returns the serial line error counters. This works only on Linux and with serial drivers supporting it.
+func (p *PortTarm) LineErrors() (LineErrors, error) {
+	return lineErrors(p.ctl)
+}
@@ -0,0 +1,22 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package com_test
+
+import (
+	"testing"
+
+	"github.com/rokath/trice/internal/com"
+	"github.com/tj/assert"
+)
+
+func TestLineErrors(t *testing.T) {
+	a := com.LineErrors{Frame: 3, Parity: 1, Overrun: 2, BufOverrun: 1, Break: 1}
+	b := com.LineErrors{Frame: 1, Overrun: 2}
+	d := a.Sub(b)
+	assert.Equal(t, com.LineErrors{Frame: 2, Parity: 1, BufOverrun: 1, Break: 1}, d)
+	assert.Equal(t, 5, d.Total())
+	assert.Equal(t, a, d.Add(b))
+	assert.Equal(t, "2 framing, 1 parity, 1 buffer overrun, 1 break", d.String())
+	assert.Equal(t, "none", com.LineErrors{}.String())
+}
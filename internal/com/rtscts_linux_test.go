@@ -0,0 +1,47 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package com_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rokath/trice/internal/com"
+	"github.com/tj/assert"
+	"golang.org/x/sys/unix"
+)
+
+// openPty returns the master file descriptor and the slave name of a new pseudo terminal.
+func openPty(t *testing.T) (int, string) {
+	fd, err := unix.Open("/dev/ptmx", unix.O_RDWR|unix.O_NOCTTY, 0)
+	if nil != err {
+		t.Skip("no pseudo terminal:", err)
+	}
+	assert.Nil(t, unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0)) // unlock
+	n, err := unix.IoctlGetInt(fd, unix.TIOCGPTN)
+	assert.Nil(t, err)
+	return fd, fmt.Sprintf("/dev/pts/%d", n)
+}
+
+func TestRTSCTS(t *testing.T) {
+	defer resetLine()
+	fd, name := openPty(t)
+	defer func() { assert.Nil(t, unix.Close(fd)) }()
+	l, err := com.ParseArgs("9600,7,E,2,rtscts")
+	assert.Nil(t, err)
+	for _, p := range []com.COMport{com.NewCOMPortGoBugSt(name, l), com.NewCOMPortTarm(name, l)} {
+		assert.True(t, p.Open())
+		slave, err := unix.Open(name, unix.O_RDWR|unix.O_NOCTTY, 0)
+		assert.Nil(t, err)
+		tio, err := unix.IoctlGetTermios(slave, unix.TCGETS)
+		assert.Nil(t, err)
+		assert.Nil(t, unix.Close(slave))
+		assert.True(t, 0 != tio.Cflag&unix.CRTSCTS)
+		assert.True(t, 0 != tio.Cflag&unix.CSTOPB) // A pseudo terminal forces 8 data bits and no parity.
+		assert.Nil(t, p.Close())
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package com
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// Reset is the signal for resetting the target after opening the serial port, "DTR", "RTS", "DTR+RTS", "break" or "" for none.
+	// It is set as command line parameter.
+	Reset string
+
+	// ResetTime is the duration of the reset pulse or the break. It is set as command line parameter.
+	ResetTime = 100 * time.Millisecond
+
+	// errNoControl is returned when the serial line signals cannot be controlled.
+	errNoControl = errors.New("serial line signals are not controllable with this driver on this OS")
+)
+
+// Signaler is implemented by serial ports able to drive the modem control lines and to send a break.
+type Signaler interface {
+	SetDTR(on bool) error
+	SetRTS(on bool) error
+	Break(d time.Duration) error
+}
+
+// CheckReset returns an error if the Reset command line parameter is no valid reset signal.
+// It is checked at log start, so a wrong value is reported before the target is connected.
+func CheckReset() error {
+	return checkReset(Reset)
+}
+
+// checkReset returns an error if signal is no valid Reset value.
+func checkReset(signal string) error {
+	switch strings.ToUpper(signal) {
+	case "", "NONE", "DTR", "RTS", "DTR+RTS", "BREAK":
+		return nil
+	}
+	return fmt.Errorf("invalid reset signal %s, expecting DTR|RTS|DTR+RTS|break|none", signal)
+}
+
+// ResetTarget sends signal over p, what is a Reset value.
+// "break" holds the line in break condition for ResetTime.
+// For a line like "DTR" it is released, asserted for ResetTime and released again,
+// what resets targets wired like Arduino-style auto-reset or with RTS at the reset pin.
+func ResetTarget(p Signaler, signal string) error {
+	if err := checkReset(signal); nil != err {
+		return err
+	}
+	switch strings.ToUpper(signal) {
+	case "BREAK":
+		return p.Break(ResetTime)
+	case "DTR":
+		return pulse(p.SetDTR)
+	case "RTS":
+		return pulse(p.SetRTS)
+	case "DTR+RTS":
+		return pulse(func(on bool) error {
+			if err := p.SetDTR(on); nil != err {
+				return err
+			}
+			return p.SetRTS(on)
+		})
+	}
+	return nil // no reset
+}
+
+// pulse uses set for releasing a line, asserting it for ResetTime and releasing it again.
+func pulse(set func(on bool) error) error {
+	if err := set(false); nil != err {
+		return err
+	}
+	time.Sleep(ResetTime)
+	if err := set(true); nil != err {
+		return err
+	}
+	time.Sleep(ResetTime)
+	return set(false)
+}
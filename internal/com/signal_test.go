@@ -0,0 +1,63 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package com_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rokath/trice/internal/com"
+	"github.com/tj/assert"
+)
+
+// signalRecorder records the line changes as strings.
+type signalRecorder []string
+
+func (p *signalRecorder) SetDTR(on bool) error {
+	*p = append(*p, fmt.Sprint("DTR=", on))
+	return nil
+}
+
+func (p *signalRecorder) SetRTS(on bool) error {
+	*p = append(*p, fmt.Sprint("RTS=", on))
+	return nil
+}
+
+func (p *signalRecorder) Break(d time.Duration) error {
+	*p = append(*p, fmt.Sprint("break ", d))
+	return nil
+}
+
+func TestResetTarget(t *testing.T) {
+	defer func(d time.Duration) { com.ResetTime = d }(com.ResetTime)
+	com.ResetTime = time.Millisecond
+	tt := []struct {
+		signal string
+		exp    []string
+	}{
+		{"DTR", []string{"DTR=false", "DTR=true", "DTR=false"}},
+		{"rts", []string{"RTS=false", "RTS=true", "RTS=false"}},
+		{"DTR+RTS", []string{"DTR=false", "RTS=false", "DTR=true", "RTS=true", "DTR=false", "RTS=false"}},
+		{"break", []string{"break 1ms"}},
+		{"none", nil},
+		{"", nil},
+	}
+	for _, x := range tt {
+		var p signalRecorder
+		assert.Nil(t, com.ResetTarget(&p, x.signal))
+		assert.Equal(t, x.exp, []string(p), x.signal)
+	}
+	var p signalRecorder
+	assert.NotNil(t, com.ResetTarget(&p, "CTS"))
+	assert.Equal(t, 0, len(p))
+}
+
+func TestCheckReset(t *testing.T) {
+	defer resetLine()
+	com.Reset = "DSR"
+	assert.NotNil(t, com.CheckReset())
+	com.Reset = "DTR"
+	assert.Nil(t, com.CheckReset())
+}
@@ -0,0 +1,124 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package com
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	serialgobugst "go.bug.st/serial"
+	"go.bug.st/serial/enumerator"
+)
+
+// ScanJSON lets Scan print the port list as JSON if set true. It is set as command line parameter.
+var ScanJSON bool
+
+// PortInfo describes a serial port. The USB data are empty for non USB ports.
+type PortInfo struct {
+	Name         string `json:"name"`
+	IsUSB        bool   `json:"isUSB"`
+	VID          string `json:"vid,omitempty"`
+	PID          string `json:"pid,omitempty"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+	Product      string `json:"product,omitempty"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+}
+
+// String returns p as one line like "/dev/ttyUSB0 USB VID:PID=0403:6001 SN=A50285BI FTDI FT232R USB UART".
+func (p PortInfo) String() string {
+	if !p.IsUSB {
+		return p.Name
+	}
+	s := fmt.Sprintf("%s USB VID:PID=%s:%s", p.Name, p.VID, p.PID)
+	if "" != p.SerialNumber {
+		s += " SN=" + p.SerialNumber
+	}
+	for _, x := range []string{p.Manufacturer, p.Product} {
+		if "" != x {
+			s += " " + x
+		}
+	}
+	return s
+}
+
+// GetPortInfos returns the present serial ports with their USB data.
+func GetPortInfos() ([]PortInfo, error) {
+	ports, err := enumerator.GetDetailedPortsList()
+	if nil != err {
+		return nil, err
+	}
+	names, _ := serialgobugst.GetPortsList() // same order, the enumerator returns no name for some port types
+	infos := make([]PortInfo, 0, len(ports))
+	for i, port := range ports {
+		p := PortInfo{Name: port.Name, IsUSB: port.IsUSB, VID: port.VID, PID: port.PID, SerialNumber: port.SerialNumber}
+		if "" == p.Name && len(names) == len(ports) {
+			p.Name = names[i]
+		}
+		if p.IsUSB {
+			p.Manufacturer, p.Product = usbStrings(p.Name)
+		}
+		infos = append(infos, p)
+	}
+	return infos, nil
+}
+
+// Scan prints the present serial ports with their USB data, as JSON if ScanJSON is true.
+func Scan() error {
+	infos, err := GetPortInfos()
+	if nil != err {
+		fmt.Println(err)
+		return err
+	}
+	if ScanJSON {
+		b, err := json.MarshalIndent(infos, "", "\t")
+		if nil != err {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	if 0 == len(infos) {
+		fmt.Println("No serial ports found!")
+	}
+	for _, p := range infos {
+		fmt.Println("Found port: ", p)
+	}
+	return nil
+}
+
+// IsUSBPort returns true if port has the form "usb:VID:PID[:serial]".
+func IsUSBPort(port string) bool {
+	return strings.HasPrefix(strings.ToLower(port), "usb:")
+}
+
+// FindUSBPort returns the name of the serial port selected with port like "usb:0403:6001" or "usb:0403:6001:A50285BI".
+// VID, PID and serial number are compared case insensitive. Without serial number the VID:PID combination must be unique.
+func FindUSBPort(port string) (string, error) {
+	s := strings.SplitN(port, ":", 4)
+	if len(s) < 3 || "" == s[1] || "" == s[2] {
+		return "", fmt.Errorf("invalid port %s, expecting usb:VID:PID[:serial]", port)
+	}
+	infos, err := GetPortInfos()
+	if nil != err {
+		return "", err
+	}
+	var names []string
+	for _, p := range infos {
+		if !p.IsUSB || !strings.EqualFold(s[1], p.VID) || !strings.EqualFold(s[2], p.PID) {
+			continue
+		}
+		if 4 == len(s) && !strings.EqualFold(s[3], p.SerialNumber) {
+			continue
+		}
+		names = append(names, p.Name)
+	}
+	switch len(names) {
+	case 0:
+		return "", fmt.Errorf("no serial port %s found, try 'trice s'", port)
+	case 1:
+		return names[0], nil
+	}
+	return "", fmt.Errorf("serial port %s is ambiguous: %v, add the USB serial number, see 'trice s'", port, names)
+}
@@ -0,0 +1,36 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package com
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// usbStrings returns the USB manufacturer and product strings of the serial port name from sysfs.
+// The serial driver enumerator does not deliver them.
+func usbStrings(name string) (manufacturer, product string) {
+	device, err := filepath.EvalSymlinks(filepath.Join("/sys/class/tty", filepath.Base(name), "device"))
+	if nil != err {
+		return
+	}
+	usbDevice := filepath.Dir(device) // usb subsystem: device is the USB interface
+	if subsystem, err := filepath.EvalSymlinks(filepath.Join(device, "subsystem")); nil == err && "usb-serial" == filepath.Base(subsystem) {
+		usbDevice = filepath.Dir(usbDevice) // usb-serial subsystem: device is the serial port below the USB interface
+	}
+	return readSysFS(usbDevice, "manufacturer"), readSysFS(usbDevice, "product")
+}
+
+// readSysFS returns the trimmed content of file in dir or "".
+func readSysFS(dir, file string) string {
+	b, err := ioutil.ReadFile(filepath.Join(dir, file))
+	if nil != err {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
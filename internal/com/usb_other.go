@@ -0,0 +1,12 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package com
+
+// usbStrings returns empty strings, because the serial driver enumerator does not deliver USB manufacturer and product strings.
+func usbStrings(name string) (manufacturer, product string) {
+	return
+}
@@ -0,0 +1,32 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package com_test
+
+import (
+	"testing"
+
+	"github.com/rokath/trice/internal/com"
+	"github.com/tj/assert"
+	"golang.org/x/sys/unix"
+)
+
+func TestWrite(t *testing.T) {
+	l := defaultLine(t)
+	fd, name := openPty(t)
+	defer func() { assert.Nil(t, unix.Close(fd)) }()
+	for _, p := range []com.COMport{com.NewCOMPortGoBugSt(name, l), com.NewCOMPortTarm(name, l)} {
+		assert.True(t, p.Open())
+		n, err := p.Write([]byte("help\r"))
+		assert.Nil(t, err)
+		assert.Equal(t, 5, n)
+		b := make([]byte, 100)
+		n, err = unix.Read(fd, b)
+		assert.Nil(t, err)
+		assert.Equal(t, "help\r", string(b[:n]))
+		assert.Nil(t, p.Close())
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"io"
+	"sync"
+
+	"github.com/rokath/trice/internal/id"
+)
+
+// Base is the common part of a decoder for an encoding implemented outside this package, see Register.
+// It buffers the input bytes, looks up the trice IDs and renders the trices like the built-in decoders,
+// including -stats counting and +cobs framing. A decoder embeds Base, calls Init in its constructor
+// and implements Read with ReadTrice. Outside this module Base is usable as github.com/rokath/trice/pkg/decoder.Base.
+type Base struct {
+	decoderData
+}
+
+// Init prepares p for decoding. The parameters are the NewDecoder parameters.
+func (p *Base) Init(lut id.TriceIDLookUp, m *sync.RWMutex, in io.Reader, endian bool) {
+	p.in = in
+	p.iBuf = make([]byte, 0, defaultSize)
+	p.lut = lut
+	p.lutMutex = m
+	p.endian = endian
+	p.inSync = true
+	p.framed = isFramed(in)
+	p.stats = decoderStats()
+}
+
+// ReadTrice reads the input bytes into the interpret buffer and calls interpret for the rendering of one trice into b.
+//
+// interpret decodes the trice at the start of Buffer with the Base methods and returns the byte count written with Sprint or OutOfSync.
+// It returns 0 and nil without removing bytes, if more bytes are needed.
+// A decoder implements its Read method as `return p.ReadTrice(b, p.interpret)`.
+func (p *Base) ReadTrice(b []byte, interpret func() (int, error)) (n int, err error) {
+	p.b = b
+	if p.framed && 0 < len(p.iBuf) { // interpret the frame rest before reading the next frame
+		cnt := len(p.iBuf)
+		n, err = interpret()
+		return p.frameEnd(cnt, n, err)
+	}
+	m, err := p.in.Read(b) // use b as intermediate read buffer to avoid allocation
+	p.iBuf = append(p.iBuf, b[:m]...)
+	p.rubbed = 0
+	if p.framed && 0 < m {
+		p.aligned = true // a new frame starts with a trice
+	}
+	if nil != err && io.EOF != err {
+		return
+	}
+	if 0 == len(p.iBuf) {
+		return // wait, err could be io.EOF
+	}
+	cnt := len(p.iBuf)
+	n, e := interpret()
+	if 0 == n && nil == e {
+		return p.frameEnd(cnt, n, err) // wait, err could be io.EOF
+	}
+	return p.frameEnd(cnt, n, e)
+}
+
+// Buffer returns the received bytes not interpreted yet. Remove interpreted bytes with Rub.
+func (p *Base) Buffer() []byte {
+	return p.iBuf
+}
+
+// Rub removes the n interpreted bytes from the Buffer start.
+func (p *Base) Rub(n int) {
+	p.rub(n)
+}
+
+// OutOfSync renders an error message from reason and detail and removes the first Buffer byte, for framed input the whole frame rest.
+// reason is the key for the decoder statistics, so it should not contain varying values. These belong into detail.
+func (p *Base) OutOfSync(reason string, detail ...interface{}) (n int, err error) {
+	return p.outOfSync(reason, detail...)
+}
+
+// Trice looks up triceID in the ID list and returns the trice type and format string.
+// ok is false for an unknown ID, which is counted in the decoder statistics.
+func (p *Base) Trice(triceID id.TriceID) (t id.TriceFmt, ok bool) {
+	LastTriceID = triceID
+	p.lutMutex.RLock()
+	p.trice, ok = p.lut[triceID]
+	p.lutMutex.RUnlock()
+	p.setTriceType()
+	p.ev = TriceEvent{ID: triceID, Cycle: -1}
+	p.rendered = false
+	p.eventTrice(triceID, ok)
+	return p.trice, ok
+}
+
+// Sprint renders the values v of the trice found with Trice with the C printf format string f like the built-in decoders
+// and returns the written byte count. The values of TRICE32F and TRICE64F trices are displayed as float and double.
+func (p *Base) Sprint(f string, v ...interface{}) int {
+	return p.sprint(f, v...)
+}
+
+// ReadU16 returns the 2 b bytes as uint16 according the endianness of the encoding name.
+func (p *Base) ReadU16(b []byte) uint16 {
+	return p.readU16(b)
+}
+
+// ReadU32 returns the 4 b bytes as uint32 according the endianness of the encoding name.
+func (p *Base) ReadU32(b []byte) uint32 {
+	return p.readU32(b)
+}
+
+// ReadU64 returns the 8 b bytes as uint64 according the endianness of the encoding name.
+func (p *Base) ReadU64(b []byte) uint64 {
+	return p.readU64(b)
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rokath/trice/pkg/cformat"
+)
+
+// xxdSpecifier is the TRICE_B format specifier for a multi-line hex dump like from the xxd tool.
+const xxdSpecifier = "%xxd"
+
+// bufferText returns the TRICE_B bytes b rendered with the format string f.
+//
+// f contains one format specifier. %xxd renders b as multi-line hex dump, see xxd.
+// A C format specifier like %02x, %3d or %c is used for each byte and the results are separated by spaces.
+// The text in front of and behind the format specifier is kept, where %% is a %.
+// Without a format specifier f is returned only.
+func bufferText(f string, b []byte) string {
+	for i := 0; i < len(f); i++ {
+		if '%' != f[i] {
+			continue
+		}
+		if strings.HasPrefix(f[i:], "%%") {
+			i++
+			continue
+		}
+		if strings.HasPrefix(f[i:], xxdSpecifier) {
+			return unescape(f[:i]) + xxd(b) + unescape(f[i+len(xxdSpecifier):])
+		}
+		j := i + 1
+		for ; j < len(f) && strings.IndexByte("-+ 0#'.0123456789hlzjtL", f[j]) >= 0; j++ {
+		}
+		if j == len(f) { // no verb
+			break
+		}
+		c := cformat.Parse(f[i : j+1])
+		s := make([]string, len(b))
+		for k, x := range b {
+			s[k] = fmt.Sprintf(c.GoFmt, x) // The bytes are unsigned.
+		}
+		return unescape(f[:i]) + strings.Join(s, " ") + unescape(f[j+1:])
+	}
+	return unescape(f)
+}
+
+// unescape returns s with %% replaced by %.
+func unescape(s string) string {
+	return strings.ReplaceAll(s, "%%", "%")
+}
+
+// xxd returns b as hex dump with 16 bytes per line like `00000010: 4865 6c6c 6f0a                           Hello.`.
+// Each line shows the offset, the bytes in groups of 2 and the printable ASCII characters, others as dot.
+// The lines are separated by a newline.
+func xxd(b []byte) string {
+	var s strings.Builder
+	for i := 0; i < len(b); i += 16 {
+		if 0 < i {
+			s.WriteByte('\n')
+		}
+		line := b[i:]
+		if 16 < len(line) {
+			line = line[:16]
+		}
+		fmt.Fprintf(&s, "%08x:", i)
+		for j := 0; j < 16; j++ {
+			if 0 == j&1 {
+				s.WriteByte(' ')
+			}
+			if j < len(line) {
+				fmt.Fprintf(&s, "%02x", line[j])
+			} else {
+				s.WriteString("  ")
+			}
+		}
+		s.WriteString("  ")
+		for _, c := range line {
+			if ' ' <= c && c <= '~' {
+				s.WriteByte(c)
+			} else {
+				s.WriteByte('.')
+			}
+		}
+	}
+	return s.String()
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/rokath/trice/internal/id"
+	"github.com/tj/assert"
+)
+
+func TestBufferText(t *testing.T) {
+	b := []byte{0, 10, 0x41, 0xff}
+	for _, x := range []struct {
+		strg, exp string
+	}{
+		{"rx: %02x\n", "rx: 00 0a 41 ff\n"},
+		{"%X|", "0 A 41 FF|"},
+		{"100%% [%3d]", "100% [  0  10  65 255]"},
+		{"%#x", "0x0 0xa 0x41 0xff"},
+		{"%hhu %d", "0 10 65 255 %d"}, // only the first specifier is used
+		{"no specifier", "no specifier"},
+		{"end %", "end %"},
+		{"%xxd", "00000000: 000a 41ff                                ..A."},
+		{"rx:\n%xxd\n", "rx:\n00000000: 000a 41ff                                ..A.\n"},
+	} {
+		assert.Equal(t, x.exp, bufferText(x.strg, b), x.strg)
+	}
+	assert.Equal(t, "[]", bufferText("[%02x]", nil))
+	assert.Equal(t, "", bufferText("%xxd", nil))
+}
+
+func TestXXD(t *testing.T) {
+	b := []byte("Hello trice world!\n\x00\x01\xec")
+	exp := "00000000: 4865 6c6c 6f20 7472 6963 6520 776f 726c  Hello trice worl\n" +
+		"00000010: 6421 0a00 01ec                           d!...."
+	assert.Equal(t, exp, xxd(b))
+	assert.Equal(t, "00000000: 3031 3233 3435 3637 3839 6162 6364 6566  0123456789abcdef", xxd([]byte("0123456789abcdef")))
+}
+
+func TestFlexBuffer(t *testing.T) {
+	lu := id.TriceIDLookUp{
+		1000: {Type: "TRICE_B", Strg: "rx: %02x\n"},
+		1001: {Type: "TRICE_B", Strg: "%xxd\n"},
+	}
+	tt := []struct {
+		endianness bool
+		in         []byte
+	}{ // The header words follow the transfer endianness, the buffer bytes keep their memory order.
+		{bigEndian, []byte{
+			0x80, 0x1f, 0x47, 0x00, 0x00, 0x05, 0xff, 0xfa, 1, 2, 3, 0xff, 0x41, 0, 0, 0, // ID 1000, long count 5
+			0x80, 0x1f, 0x4b, 0x01, 0x48, 0x69, 0x0a, 0x00, // ID 1001, count 3
+		}},
+		{littleEndian, []byte{
+			0x00, 0x47, 0x1f, 0x80, 0xfa, 0xff, 0x05, 0x00, 1, 2, 3, 0xff, 0x41, 0, 0, 0, // ID 1000, long count 5
+			0x01, 0x4b, 0x1f, 0x80, 0x48, 0x69, 0x0a, 0x00, // ID 1001, count 3
+		}},
+	}
+	for _, x := range tt {
+		dec := NewFlexDecoder(lu, new(sync.RWMutex), bytes.NewReader(x.in), x.endianness)
+		e := readEvents(t, dec)
+		assert.Equal(t, 2, len(e))
+		assert.Equal(t, "rx: 01 02 03 ff 41\n", e[0].Text)
+		assert.Equal(t, []interface{}{[]byte{1, 2, 3, 0xff, 0x41}}, e[0].Values)
+		assert.Equal(t, "TRICE_B", e[0].Type)
+		assert.Equal(t, "00000000: 4869 0a                                  Hi.\n", e[1].Text)
+		assert.Equal(t, 1, e[1].Cycle)
+	}
+
+	in := []byte{0x80, 0x1f, 0x4b, 0x00, 0x48, 0x69, 0x0a, 0x01} // padding byte not 0
+	act := readAll(NewFlexDecoder(lu, new(sync.RWMutex), bytes.NewReader(in), bigEndian))
+	assert.True(t, 0 < len(act) && "error: padding bytes not zero" == act[0][:29], act)
+}
@@ -0,0 +1,90 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// errCOBS is the decoding error for a corrupted COBS frame.
+var errCOBS = errors.New("COBS code byte points behind the frame end")
+
+// cobsReader removes the COBS framing (Consistent Overhead Byte Stuffing) from the inner reader bytes.
+// Each frame is terminated by a 0 byte. A Read delivers one complete decoded frame, so the decoder can discard
+// bytes not forming a complete trice at the frame end and is in sync again with the next frame.
+type cobsReader struct {
+	in    io.Reader
+	rBuf  []byte      // received bytes not forming a complete frame yet
+	err   error       // inner reader error, returned after the last complete frame
+	stats *statistics // counts the discarded frames
+}
+
+// newCOBSReader returns a reader delivering the COBS decoded frames from in.
+func newCOBSReader(in io.Reader) io.Reader {
+	return &cobsReader{in: in, rBuf: make([]byte, 0, defaultSize), stats: decoderStats()}
+}
+
+// Read returns the next complete decoded frame.
+// Empty frames are skipped. Corrupted frames and frames longer than len(b) are discarded as a whole.
+// n is 0 when the inner reader has delivered no frame end yet.
+func (p *cobsReader) Read(b []byte) (n int, err error) {
+	for {
+		if i := bytes.IndexByte(p.rBuf, 0); 0 <= i {
+			frame := p.rBuf[:i]
+			p.rBuf = p.rBuf[i+1:]
+			if 0 == len(frame) {
+				continue // 2 delimiters in a row
+			}
+			d, e := cobsDecode(frame)
+			reason := "corrupted COBS frame"
+			if nil == e && len(b) < len(d) {
+				e = fmt.Errorf("decoded COBS frame with %d bytes is longer than the read buffer with %d bytes", len(d), len(b))
+				reason = "COBS frame longer than read buffer"
+			}
+			if nil != e {
+				p.stats.discard(reason, len(frame))
+				if Verbose {
+					fmt.Println(e, "- discarding frame", frame)
+				}
+				continue
+			}
+			return copy(b, d), nil
+		}
+		if nil != p.err {
+			return 0, p.err
+		}
+		var m int
+		m, p.err = p.in.Read(b) // use b as intermediate read buffer to avoid allocation
+		p.rBuf = append(p.rBuf, b[:m]...)
+		if 0 == m && nil == p.err {
+			return // wait
+		}
+	}
+}
+
+// Framed returns true, because each Read delivers one complete frame.
+func (p *cobsReader) Framed() bool {
+	return true
+}
+
+// cobsDecode returns the decoded COBS frame f without the 0 delimiter.
+// Each code byte c is followed by c-1 data bytes and stands for a 0 byte after them, except for c == 255 and at the frame end.
+func cobsDecode(f []byte) (d []byte, err error) {
+	d = make([]byte, 0, len(f))
+	for 0 < len(f) {
+		c := int(f[0])
+		if 0 == c || len(f) < c {
+			return nil, errCOBS
+		}
+		d = append(d, f[1:c]...)
+		f = f[c:]
+		if 0 < len(f) && 0xff != c {
+			d = append(d, 0)
+		}
+	}
+	return
+}
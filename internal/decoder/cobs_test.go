@@ -0,0 +1,130 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/rokath/trice/internal/receiver"
+	"github.com/tj/assert"
+)
+
+// cobsEncode returns d COBS encoded with the 0 delimiter.
+func cobsEncode(d []byte) (f []byte) {
+	f = []byte{0} // code byte placeholder
+	c := 0        // code byte index
+	for _, b := range d {
+		if 0 != b {
+			f = append(f, b)
+		}
+		if 0 == b || 0xfe == len(f)-c-1 {
+			f[c] = byte(len(f) - c)
+			c = len(f)
+			f = append(f, 0)
+		}
+	}
+	f[c] = byte(len(f) - c)
+	return append(f, 0)
+}
+
+func TestCOBSDecode(t *testing.T) {
+	long := make([]byte, 300)
+	for i := range long {
+		long[i] = byte(i%255 + 1)
+	}
+	for _, d := range [][]byte{
+		{},
+		{0},
+		{0, 0},
+		{1, 2, 0, 3},
+		{0x11, 0x22, 0x00, 0x33},
+		{0x11, 0x00, 0x00, 0x00},
+		long[:254],
+		long[:255],
+		long,
+	} {
+		f := cobsEncode(d)
+		assert.Equal(t, -1, bytes.IndexByte(f[:len(f)-1], 0))
+		act, err := cobsDecode(f[:len(f)-1])
+		assert.Nil(t, err)
+		assert.Equal(t, d, act)
+	}
+	assert.Equal(t, []byte{3, 0x11, 0x22, 2, 0x33, 0}, cobsEncode([]byte{0x11, 0x22, 0x00, 0x33}))
+	_, err := cobsDecode([]byte{5, 1, 2})
+	assert.Equal(t, errCOBS, err)
+}
+
+// byteReader delivers one byte with each Read.
+type byteReader struct {
+	b []byte
+}
+
+func (p *byteReader) Read(b []byte) (n int, err error) {
+	if 0 == len(p.b) {
+		return 0, io.EOF
+	}
+	b[0], p.b = p.b[0], p.b[1:]
+	return 1, nil
+}
+
+func TestCOBSReader(t *testing.T) {
+	defer withStats()()
+	var in []byte
+	in = append(in, 0x22, 0x11, 0) // rest of a frame with lost start
+	in = append(in, cobsEncode([]byte{1, 0, 2})...)
+	in = append(in, 0)          // empty frame
+	in = append(in, 9, 1, 2, 0) // corrupted frame
+	in = append(in, cobsEncode([]byte{3})...)
+	in = append(in, 4, 5) // incomplete frame
+	r := newCOBSReader(&byteReader{in})
+	b := make([]byte, defaultSize)
+	var frames [][]byte
+	for {
+		n, err := r.Read(b)
+		if 0 < n {
+			frames = append(frames, append([]byte{}, b[:n]...))
+		}
+		if io.EOF == err {
+			break
+		}
+	}
+	assert.Equal(t, [][]byte{{1, 0, 2}, {3}}, frames)
+	assert.Equal(t, map[string]int{"corrupted COBS frame": 2}, sessionStats.outOfSync)
+	assert.Equal(t, 5, sessionStats.discarded)
+	f, ok := r.(receiver.Framer)
+	assert.True(t, ok && f.Framed())
+}
+
+func TestCOBSReaderLongFrame(t *testing.T) {
+	defer withStats()()
+	in := cobsEncode([]byte{1, 2, 3, 4, 5})
+	in = append(in, cobsEncode([]byte{6})...)
+	r := newCOBSReader(bytes.NewReader(in))
+	b := make([]byte, 4)
+	n, err := r.Read(b)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{6}, b[:n])
+	assert.Equal(t, map[string]int{"COBS frame longer than read buffer": 1}, sessionStats.outOfSync)
+}
+
+func TestFlexCOBS(t *testing.T) {
+	defer func(s string) { Encoding = s }(Encoding)
+	Encoding = "flexL+cobs"
+	lu := tilLookUp(t)
+	var in []byte
+	in = append(in, cobsEncode([]byte{1, 124, 227, 255, 0, 0, 4, 0, 2, 124, 227, 255, 1, 0, 8, 0})...) // trice batch
+	in = append(in, cobsEncode([]byte{3, 124, 227, 0, 2, 0, 8, 0, 4, 124, 227, 255, 3, 0, 24, 0})...)  // corrupted ID
+	in = append(in, cobsEncode([]byte{5, 124, 227, 255, 4, 0, 24, 0})...)
+	dec, err := newEncodingDecoder(lu, new(sync.RWMutex), bytes.NewReader(in))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		`MSG: triceFifoMaxDepth = 4, select = 0\n`,
+		`MSG: triceFifoMaxDepth = 8, select = 1\n`,
+		"error: unknown triceID   227 ignoring frame rest of 16 bytes [3 124 227 0 2 0 8 0]\n",
+		"warning:Cycle 5 does not match expected cyle 3 - lost trice messages?\n" + `MSG: triceFifoMaxDepth = 24, select = 4\n`,
+	}, readAll(dec))
+}
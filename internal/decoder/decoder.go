@@ -5,20 +5,18 @@
 package decoder
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"io"
-	"log"
-	"os"
-	"os/signal"
-	"regexp"
+	"strings"
 	"sync"
-	"syscall"
 	"time"
 
 	"github.com/rokath/trice/internal/emitter"
 	"github.com/rokath/trice/internal/id"
 	"github.com/rokath/trice/internal/receiver"
+	"github.com/rokath/trice/pkg/cformat"
 	"github.com/rokath/trice/pkg/msg"
 )
 
@@ -31,17 +29,6 @@ const (
 
 	// flag
 	bigEndian = false
-
-	// patNextFormatSpezifier is a regex to find next format specifier in a string (exclude %%*)
-	patNextFormatSpezifier = `(?:^|[^%])(%[0-9\.#]*(b|c|d|u|x|X|o|f))`
-
-	// patNextFormatSpezifier is a regex to find next format u specifier in a string
-	// It does also match %%u positions! so an additional check must follow.
-	patNextFormatUSpezifier = `(?:%[0-9]*u)`
-
-	// patNextFormatSpezifier is a regex to find next format u specifier in a string
-	// It does also match %%u positions! so an additional check must follow.
-	patNextFormatXSpezifier = `(?:%[0-9]*(x|X|b))`
 )
 
 var (
@@ -60,22 +47,15 @@ var (
 	// TestTableMode is a special option for easy decoder test table generation.
 	TestTableMode bool
 
-	// UnsignedHex if true, forces hex and in values printed as unsigned values.
+	// UnsignedHex if true, forces hex, octal and binary values printed as unsigned values like in C.
 	UnsignedHex bool
-
-	matchNextFormatSpezifier  = regexp.MustCompile(patNextFormatSpezifier)
-	matchNextFormatUSpezifier = regexp.MustCompile(patNextFormatUSpezifier)
-	matchNextFormatXSpezifier = regexp.MustCompile(patNextFormatXSpezifier)
 )
 
-// newDecoder abstracts the function type for a new decoder.
-type newDecoder func(lut id.TriceIDLookUp, m *sync.RWMutex, in io.Reader, endian bool) Decoder
-
 // Decoder is providing a byte reader returning decoded trice's.
-// setInput allows switching the input stream to a different source.
+// SetInput allows switching the input stream to a different source.
 type Decoder interface {
 	io.Reader
-	setInput(io.Reader)
+	SetInput(io.Reader)
 }
 
 // decoderData is the common data struct for all decoders.
@@ -89,63 +69,158 @@ type decoderData struct {
 	lutMutex           *sync.RWMutex    // to avoid concurrent map read and map write during map refresh triggered by filewatcher
 	trice              id.TriceFmt      // id.TriceFmt // received trice
 	upperCaseTriceType string           // This is the to upper case converted received trice type.
+	float              bool             // The received trice type is TRICE32F or TRICE64F.
 	b                  []byte           // read buffer
 	lastInnerRead      time.Time
 	innerReadInterval  time.Duration
+	framed             bool                           // inner reader delivers complete frames, see receiver.Framer
+	pos                int64                          // count of removed bytes from the interpret buffer, which is the input stream offset of iBuf[0]
+	ev                 TriceEvent                     // actual trice data for ReadEvent
+	rendered           bool                           // ev belongs to a rendered trice
+	stats              *statistics                    // decoder statistics or nil
+	aligned            bool                           // iBuf[0] is a trice start, because the bytes in front were decoded
+	formats            map[id.TriceID]*cformat.Format // parsed format strings per ID
+	prefix             string                         // text in front of the next rendered trice like a cycle warning
 }
 
-// setInput allows switching the input stream to a different source.
+// setTriceType sets p.upperCaseTriceType for p.trice.Type.
+// The float types TRICE32F and TRICE64F are set as TRICE32 and TRICE64 with p.float true.
+func (p *decoderData) setTriceType() {
+	t := strings.ToUpper(p.trice.Type) // for trice* too
+	p.float = strings.HasPrefix(t, "TRICE32F") || strings.HasPrefix(t, "TRICE64F")
+	if p.float {
+		t = t[:len("TRICE32")] + t[len("TRICE32F"):]
+	}
+	p.upperCaseTriceType = t
+}
+
+// statsUser is a decoder using statistics.
+type statsUser interface {
+	setStats(*statistics)
+}
+
+// setStats sets the statistics the decoder counts into. s == nil switches counting off.
+func (p *decoderData) setStats(s *statistics) {
+	p.stats = s
+}
+
+// SetInput allows switching the input stream to a different source.
 //
 // This function is for easier testing with cycle counters.
-func (p *decoderData) setInput(r io.Reader) {
+func (p *decoderData) SetInput(r io.Reader) {
 	p.in = r
+	p.framed = isFramed(r)
 }
 
-func handleSIGTERM(rc io.ReadCloser) {
-	// prepare CTRL-C shutdown reaction
-	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
-	ticker := time.NewTicker(50 * time.Millisecond)
-	for {
-		select {
-		case sig := <-sigs: // wait for a signal
-			if Verbose {
-				fmt.Println("####################################", sig, "####################################")
-			}
-			msg.FatalOnErr(rc.Close())
-			os.Exit(0) // end
-		case <-ticker.C:
-		}
+// isFramed returns true if in delivers one complete frame with each Read.
+func isFramed(in io.Reader) bool {
+	f, ok := in.(receiver.Framer)
+	return ok && f.Framed()
+}
+
+// frameEnd discards not interpretable bytes at the end of a frame.
+// cnt is the interpret buffer size before the interpretation, which returned n and err.
+// If nothing was consumed, the decoder waits for more bytes, what cannot happen inside a frame.
+// For not framed input frameEnd returns n and err unchanged.
+func (p *decoderData) frameEnd(cnt, n int, err error) (int, error) {
+	if !p.framed || 0 != n || nil != err || 0 == cnt || cnt != len(p.iBuf) {
+		return n, err
 	}
+	n = copy(p.b, fmt.Sprintln("error:incomplete trice at frame end, ignoring", p.iBuf))
+	p.stats.discard("incomplete trice at frame end", cnt)
+	p.rub(cnt)
+	return n, nil
 }
 
 // Translate performs the trice log task.
 // Bytes are read with rc. Then according decoder.Encoding they are translated into strings.
 // Each read returns the amount of bytes for one trice. rc is called on every
-// Translate returns true on io.EOF or false on hard read error or sigterm.
+// Translate returns io.EOF at the end of a predefined buffer or a not followed file or the read error, for example when a TCP connection was lost.
+// A new decoder is used on each call, so after a re-connect decoding starts in sync and without old buffered bytes.
+// CTRL-C is not handled here, because it needs to work also between the calls during a re-connect.
 func Translate(sw *emitter.TriceLineComposer, lut id.TriceIDLookUp, m *sync.RWMutex, rc io.ReadCloser) error {
-	var dec Decoder //io.Reader
-	switch Encoding {
-	case "esc", "ESC":
-		dec = NewEscDecoder(lut, m, rc, bigEndian)
-	case "flex", "FLEX":
-		dec = NewFlexDecoder(lut, m, rc, bigEndian)
-	case "flexl", "flexL", "FLEXL":
-		dec = NewFlexDecoder(lut, m, rc, littleEndian)
-	default:
-		log.Fatalf(fmt.Sprintln("unknown encoding ", Encoding))
+	dec, err := newEncodingDecoder(lut, m, rc)
+	if nil != err {
+		return err
 	}
-	go handleSIGTERM(rc)
 	return decodeAndComposeLoop(sw, dec)
 }
 
+// newEncodingDecoder returns a decoder for in according decoder.Encoding, see Register.
+func newEncodingDecoder(lut id.TriceIDLookUp, m *sync.RWMutex, in io.Reader) (Decoder, error) {
+	r, endian, err := Lookup(Encoding)
+	if nil != err {
+		return nil, err
+	}
+	if _, framing, _ := splitFraming(Encoding); nil != framing {
+		in = framing(in)
+	}
+	return r.New(lut, m, in, endian), nil
+}
+
+// Plausibility decodes b according decoder.Encoding and returns the count of valid trices and the count of re-syncs.
+// Sync packets and trices with an ID from lut count as valid trices. Decoding errors in a row count as one re-sync.
+// Trices with unknown IDs, like the flex placeholders, count as neither, because a wrong baud rate produces them too.
+// Errors in front of the first valid trice are not counted, because b can start inside a trice.
+// It is used to check if b was received with the right baud rate.
+func Plausibility(lut id.TriceIDLookUp, m *sync.RWMutex, b []byte) (valid, resyncs int) {
+	r := bytes.NewReader(b)
+	dec, err := newEncodingDecoder(lut, m, r)
+	if nil != err {
+		return
+	}
+	if u, ok := dec.(statsUser); ok {
+		u.setStats(nil) // a plausibility check is no logging
+	}
+	ed, _ := dec.(EventDecoder)
+	s := make([]byte, defaultSize)
+	var inError bool
+	for {
+		n, triceID, err := readTrice(dec, ed, s)
+		switch {
+		case 0 == n && (nil == err || io.EOF == err): // wait
+			if 0 == r.Len() { // nothing more to interpret
+				return
+			}
+		case nil != err && io.EOF != err, strings.HasPrefix(string(s[:n]), "error:"):
+			if !inError && 0 < valid {
+				resyncs++
+			}
+			inError = true
+		case emitter.SyncPacketPattern == string(s[:n]) || knownID(lut, m, triceID):
+			valid++
+			inError = false
+		}
+	}
+}
+
+// knownID returns true if triceID is in lut.
+func knownID(lut id.TriceIDLookUp, m *sync.RWMutex, triceID id.TriceID) bool {
+	m.RLock()
+	defer m.RUnlock()
+	_, ok := lut[triceID]
+	return ok
+}
+
 func decodeAndComposeLoop(sw *emitter.TriceLineComposer, dec Decoder) error {
 	// intermediate trice string buffer for a single trice
 	b := make([]byte, defaultSize)
+	var watch lineErrorWatch
+	ed, _ := dec.(EventDecoder)
+	lastReport := time.Now()
 	for {
-		n, err := dec.Read(b) // Code to measure
+		if Stats && 0 < StatsInterval && time.Since(lastReport) >= StatsInterval {
+			lastReport = time.Now()
+			if 0 < len(sw.Line) { // complete a started line
+				_, err := sw.WriteString("\n")
+				msg.OnErr(err)
+			}
+			_, err := sw.WriteString(StatsReport())
+			msg.OnErr(err)
+		}
+		n, triceID, err := readTrice(dec, ed, b) // Code to measure
 		if io.EOF == err {
-			if "BUFFER" == receiver.Port { // do not wait for a predefined buffer
+			if receiver.Finite() { // do not wait for a predefined buffer or a not followed file
 				return err
 			}
 			if Verbose {
@@ -158,15 +233,22 @@ func decodeAndComposeLoop(sw *emitter.TriceLineComposer, dec Decoder) error {
 			continue                           // read again
 		}
 		if nil != err {
-			if Verbose {
-				fmt.Println(err)
-			}
-			return nil // try again
+			return err // the caller decides about a re-connect
 		}
 		start := time.Now()
+		if 0 < n {
+			if s := watch.trice(string(b[:n]), start); "" != s {
+				if 0 < len(sw.Line) { // complete a started line
+					_, err := sw.WriteString("\n")
+					msg.OnErr(err)
+				}
+				_, err := sw.WriteString(s)
+				msg.OnErr(err)
+			}
+		}
 		if 0 < n && "" != ShowID && 0 == len(sw.Line) {
 			// dec.Read can return n=0 in some cases and then wait.
-			s := fmt.Sprintf(ShowID, LastTriceID)
+			s := fmt.Sprintf(ShowID, triceID)
 			_, err := sw.Write([]byte(s))
 			msg.OnErr(err)
 		}
@@ -179,6 +261,17 @@ func decodeAndComposeLoop(sw *emitter.TriceLineComposer, dec Decoder) error {
 	}
 }
 
+// readTrice reads the next trice into b with ed, if not nil, otherwise with dec.
+// triceID is the decoded trice ID, for decoders without ReadEvent it is LastTriceID.
+func readTrice(dec Decoder, ed EventDecoder, b []byte) (n int, triceID id.TriceID, err error) {
+	if nil == ed {
+		n, err = dec.Read(b)
+		return n, LastTriceID, err
+	}
+	e, err := ed.ReadEvent(b)
+	return len(e.Text), e.ID, err
+}
+
 // readU16 returns the 2 b bytes as uint16 according the specified endianness
 func (p *decoderData) readU16(b []byte) uint16 {
 	if littleEndian == p.endian {
@@ -228,44 +321,29 @@ func (p *decoderData) rub(n int) {
 		}
 	}
 	p.rubbed += n
+	p.pos += int64(n)
 	p.iBuf = p.iBuf[n:]
 }
 
-// outOfSync generates an error message and removes first byte in input buffer.
-func (p *decoderData) outOfSync(msg string) (n int, e error) {
+// outOfSync generates an error message from reason and detail and removes first byte in input buffer.
+// For framed input the whole frame rest is removed, because the decoder is in sync again with the next frame.
+// reason is also the key for the decoder statistics, so it should not contain varying values. These belong into detail.
+func (p *decoderData) outOfSync(reason string, detail ...interface{}) (n int, e error) {
 	cnt := len(p.iBuf)
 	if cnt > 8 {
 		cnt = 8
 	}
-	n = copy(p.b, fmt.Sprintln("error:", msg, "ignoring first byte", p.iBuf[0:cnt]))
 	p.inSync = false
+	p.aligned = false
+	s := strings.TrimSuffix(fmt.Sprintln(append([]interface{}{"error:", reason}, detail...)...), "\n")
+	if p.framed {
+		n = copy(p.b, fmt.Sprintln(s, "ignoring frame rest of", len(p.iBuf), "bytes", p.iBuf[0:cnt]))
+		p.stats.discard(reason, len(p.iBuf))
+		p.rub(len(p.iBuf))
+		return
+	}
+	n = copy(p.b, fmt.Sprintln(s, "ignoring first byte", p.iBuf[0:cnt]))
+	p.stats.discard(reason, 1)
 	p.rub(1)
 	return
 }
-
-// uReplaceN checks all format specifier in i and replaces %nu with %nd and returns that result as o.
-// If a replacement took place on position k u[k] is true. Afterwards len(u) is amount of found format specifiers.
-func uReplaceN(i string) (o string, u []bool) {
-	o = i
-	s := i
-	var offset int
-	for {
-		loc := matchNextFormatSpezifier.FindStringIndex(s)
-		if nil == loc { // no (more) fm found
-			return
-		}
-		offset += loc[1] // track position
-		fm := s[loc[0]:loc[1]]
-		locU := matchNextFormatUSpezifier.FindStringIndex(fm)
-		locX := matchNextFormatXSpezifier.FindStringIndex(fm)
-		if nil != locU { // a %nu found
-			o = o[:offset-1] + "d" + o[offset:] // replace %nu -> %nd
-			u = append(u, true)
-		} else if nil != locX && UnsignedHex { // a %nx or %nX or %nb found
-			u = append(u, true) // no negative values
-		} else { // keep sign
-			u = append(u, false)
-		}
-		s = i[offset:] // remove processed part
-	}
-}
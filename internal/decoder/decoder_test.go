@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
 	"strings"
 	"sync"
 	"testing"
@@ -43,6 +44,29 @@ func TestTranslate(t *testing.T) {
 	assert.Equal(t, io.EOF, err)
 }
 
+func TestTranslateFILE(t *testing.T) {
+	glob.Lock()
+	defer glob.Unlock()
+	sw := emitter.New()
+	lu := make(id.TriceIDLookUp) // empty
+	assert.Nil(t, lu.FromJSON([]byte(til)))
+	m := new(sync.RWMutex) // m is a pointer to a read write mutex for lu
+	Encoding = "flexL"
+	f, err := ioutil.TempFile("", "trice*.bin")
+	assert.Nil(t, err)
+	defer func() { assert.Nil(t, os.Remove(f.Name())) }()
+	_, err = f.Write([]byte{2, 124, 227, 255, 0, 0, 4, 0})
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+	save := receiver.Port
+	defer func() { receiver.Port = save }()
+	receiver.Port = "FILE"
+	rc, err := receiver.NewReadCloser(receiver.Port, f.Name())
+	assert.Nil(t, err)
+	err = Translate(sw, lu, m, rc)
+	assert.Equal(t, io.EOF, err) // file end without -follow
+}
+
 // testTable ist a slice of structs generated by the trice tool -testTable option.
 type testTable []struct {
 	in  []byte // byte buffer sequence
@@ -50,7 +74,7 @@ type testTable []struct {
 }
 
 // doTableTest is the universal decoder test sequence.
-func doTableTest(t *testing.T, f newDecoder, endianness bool, teTa testTable) {
+func doTableTest(t *testing.T, f NewDecoder, endianness bool, teTa testTable) {
 	lu := make(id.TriceIDLookUp)
 	luM := new(sync.RWMutex)
 	assert.Nil(t, lu.FromJSON([]byte(til)))
@@ -59,7 +83,7 @@ func doTableTest(t *testing.T, f newDecoder, endianness bool, teTa testTable) {
 	dec := f(lu, luM, nil, endianness) // p is a new decoder instance
 	for _, x := range teTa {
 		in := ioutil.NopCloser(bytes.NewBuffer(x.in))
-		dec.setInput(in)
+		dec.SetInput(in)
 		lineStart := true
 		var err error
 		var n int
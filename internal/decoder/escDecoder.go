@@ -7,7 +7,6 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
-	"strings"
 	"sync"
 
 	"github.com/rokath/trice/internal/id"
@@ -29,6 +28,8 @@ func NewEscDecoder(lut id.TriceIDLookUp, m *sync.RWMutex, in io.Reader, endian b
 	p.lut = lut
 	p.lutMutex = m
 	p.endian = endian // esc format is only big endian
+	p.framed = isFramed(in)
+	p.stats = decoderStats()
 	return p
 }
 
@@ -47,24 +48,39 @@ func (p *Esc) Read(b []byte) (n int, err error) {
 		return
 	}
 
-	// use b as intermediate read buffer to avoid allocation
-	n, err = p.in.Read(b)
-	// p.syncBuffer can contain unprocessed bytes from last call.
-	p.iBuf = append(p.iBuf, b[:n]...) // merge with leftovers
-	n = 0
-	if nil != err && io.EOF != err {
-		n = copy(b, fmt.Sprintln("error:internal reader error ", err))
-		return
+	p.b = b
+	if !p.framed || 0 == len(p.iBuf) { // in frame mode interpret the frame rest before reading the next frame
+		// use b as intermediate read buffer to avoid allocation
+		n, err = p.in.Read(b)
+		// p.syncBuffer can contain unprocessed bytes from last call.
+		p.iBuf = append(p.iBuf, b[:n]...) // merge with leftovers
+		n = 0
+		if nil != err && io.EOF != err {
+			n = copy(b, fmt.Sprintln("error:internal reader error ", err))
+			return
+		}
 	}
 
 	// Even err could be io.EOF some valid data possibly in p.syncBuffer.
 	// In case of file input (JLINK usage) a plug off is not detectable here.
 
+	cnt := len(p.iBuf)
+	if !p.framed && cnt < 4 {
+		return // wait
+	}
+	n, e := p.interpret()
+	if 0 < n || nil != e { // on wait keep a possible io.EOF
+		err = e
+	}
+	return p.frameEnd(cnt, n, err)
+}
+
+// interpret returns one trice string from the interpret buffer or nothing, if more bytes are needed.
+func (p *Esc) interpret() (n int, err error) {
 	p.bc = len(p.iBuf) // intermediade assignment for better error tracking
 	if p.bc < 4 {
 		return // wait
 	}
-	p.b = b
 	if 0xec != p.iBuf[0] { // 0xec == 236
 		return p.outOfSync("start byte is not 0xEC")
 	}
@@ -77,13 +93,14 @@ func (p *Esc) Read(b []byte) (n int, err error) {
 	p.lutMutex.RLock()
 	p.trice, ok = p.lut[triceID]
 	p.lutMutex.RUnlock()
+	p.eventTrice(triceID, ok)
 	if !ok { // unknown id
-		return p.outOfSync(fmt.Sprint("unknown ID ", triceID))
+		return p.outOfSync("unknown ID", triceID)
 	}
-	p.upperCaseTriceType = strings.ToUpper(p.trice.Type) // for trice* too
-	p.bc = p.bytesCount(lengthCode)                      // payload plus header
+	p.setTriceType()
+	p.bc = p.bytesCount(lengthCode) // payload plus header
 	if p.expectedByteCount() != p.bc {
-		return p.outOfSync(fmt.Sprint("trice.Type ", p.trice.Type, " with not matching length code ", lengthCode))
+		return p.outOfSync("not matching length code", lengthCode, "for trice.Type", p.trice.Type)
 	}
 	if len(p.iBuf) < 4+p.bc { // header plus payload
 		return // wait
@@ -163,7 +180,7 @@ func (p *Esc) sprintTrice() (n int, e error) {
 			return s.triceFn(p)
 		}
 	}
-	return p.outOfSync(fmt.Sprintf("Unexpected trice.Type %s", p.trice.Type))
+	return p.outOfSync("Unexpected trice.Type", p.trice.Type)
 }
 
 func (p *Esc) triceS() (n int, e error) {
@@ -188,206 +205,206 @@ func (p *Esc) triceS() (n int, e error) {
 		}
 	}
 	// ok
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, string(b[:i])))
+	n = p.sprint(p.trice.Strg, string(b[:i]))
 	p.rub(4 + p.bc)
 	return
 }
 
 func (p *Esc) trice0() (n int, e error) {
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg))
+	n = p.sprint(p.trice.Strg)
 	return
 }
 
 func (p *Esc) trice81() (n int, e error) {
-	b0 := int8(p.iBuf[0]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, b0))
+	b0 := int8(p.iBuf[0])
+	n = p.sprint(p.trice.Strg, b0)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice82() (n int, e error) {
-	b0 := int8(p.iBuf[0]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b1 := int8(p.iBuf[1]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, b0, b1))
+	b0 := int8(p.iBuf[0])
+	b1 := int8(p.iBuf[1])
+	n = p.sprint(p.trice.Strg, b0, b1)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice83() (n int, e error) {
-	b0 := int8(p.iBuf[0]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b1 := int8(p.iBuf[1]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b2 := int8(p.iBuf[2]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b3 := int8(p.iBuf[3]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
+	b0 := int8(p.iBuf[0])
+	b1 := int8(p.iBuf[1])
+	b2 := int8(p.iBuf[2])
+	b3 := int8(p.iBuf[3])
 	if 0 != b3 {
 		return p.outOfSync("padding byte not zero")
 	}
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, b0, b1, b2))
+	n = p.sprint(p.trice.Strg, b0, b1, b2)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice84() (n int, e error) {
-	b0 := int8(p.iBuf[0]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b1 := int8(p.iBuf[1]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b2 := int8(p.iBuf[2]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b3 := int8(p.iBuf[3]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, b0, b1, b2, b3))
+	b0 := int8(p.iBuf[0])
+	b1 := int8(p.iBuf[1])
+	b2 := int8(p.iBuf[2])
+	b3 := int8(p.iBuf[3])
+	n = p.sprint(p.trice.Strg, b0, b1, b2, b3)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice85() (n int, e error) {
-	b0 := int8(p.iBuf[0]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b1 := int8(p.iBuf[1]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b2 := int8(p.iBuf[2]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b3 := int8(p.iBuf[3]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b4 := int8(p.iBuf[4]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b5 := int8(p.iBuf[5]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b6 := int8(p.iBuf[6]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b7 := int8(p.iBuf[7]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
+	b0 := int8(p.iBuf[0])
+	b1 := int8(p.iBuf[1])
+	b2 := int8(p.iBuf[2])
+	b3 := int8(p.iBuf[3])
+	b4 := int8(p.iBuf[4])
+	b5 := int8(p.iBuf[5])
+	b6 := int8(p.iBuf[6])
+	b7 := int8(p.iBuf[7])
 	if 0 != b7 || 0 != b6 || 0 != b5 {
 		return p.outOfSync("padding bytes not zero")
 	}
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, b0, b1, b2, b3, b4))
+	n = p.sprint(p.trice.Strg, b0, b1, b2, b3, b4)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice86() (n int, e error) {
-	b0 := int8(p.iBuf[0]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b1 := int8(p.iBuf[1]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b2 := int8(p.iBuf[2]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b3 := int8(p.iBuf[3]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b4 := int8(p.iBuf[4]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b5 := int8(p.iBuf[5]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b6 := int8(p.iBuf[6]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b7 := int8(p.iBuf[7]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
+	b0 := int8(p.iBuf[0])
+	b1 := int8(p.iBuf[1])
+	b2 := int8(p.iBuf[2])
+	b3 := int8(p.iBuf[3])
+	b4 := int8(p.iBuf[4])
+	b5 := int8(p.iBuf[5])
+	b6 := int8(p.iBuf[6])
+	b7 := int8(p.iBuf[7])
 	if 0 != b7 || 0 != b6 {
 		return p.outOfSync("padding bytes not zero")
 	}
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, b0, b1, b2, b3, b4, b5))
+	n = p.sprint(p.trice.Strg, b0, b1, b2, b3, b4, b5)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice87() (n int, e error) {
-	b0 := int8(p.iBuf[0]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b1 := int8(p.iBuf[1]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b2 := int8(p.iBuf[2]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b3 := int8(p.iBuf[3]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b4 := int8(p.iBuf[4]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b5 := int8(p.iBuf[5]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b6 := int8(p.iBuf[6]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b7 := int8(p.iBuf[7]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
+	b0 := int8(p.iBuf[0])
+	b1 := int8(p.iBuf[1])
+	b2 := int8(p.iBuf[2])
+	b3 := int8(p.iBuf[3])
+	b4 := int8(p.iBuf[4])
+	b5 := int8(p.iBuf[5])
+	b6 := int8(p.iBuf[6])
+	b7 := int8(p.iBuf[7])
 	if 0 != b7 {
 		return p.outOfSync("padding byte not zero")
 	}
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, b0, b1, b2, b3, b4, b5, b6))
+	n = p.sprint(p.trice.Strg, b0, b1, b2, b3, b4, b5, b6)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice88() (n int, e error) {
-	b0 := int8(p.iBuf[0]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b1 := int8(p.iBuf[1]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b2 := int8(p.iBuf[2]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b3 := int8(p.iBuf[3]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b4 := int8(p.iBuf[4]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b5 := int8(p.iBuf[5]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b6 := int8(p.iBuf[6]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	b7 := int8(p.iBuf[7]) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, b0, b1, b2, b3, b4, b5, b6, b7))
+	b0 := int8(p.iBuf[0])
+	b1 := int8(p.iBuf[1])
+	b2 := int8(p.iBuf[2])
+	b3 := int8(p.iBuf[3])
+	b4 := int8(p.iBuf[4])
+	b5 := int8(p.iBuf[5])
+	b6 := int8(p.iBuf[6])
+	b7 := int8(p.iBuf[7])
+	n = p.sprint(p.trice.Strg, b0, b1, b2, b3, b4, b5, b6, b7)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice161() (n int, e error) {
-	d0 := int16(p.readU16(p.iBuf[0:2])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, d0))
+	d0 := int16(p.readU16(p.iBuf[0:2]))
+	n = p.sprint(p.trice.Strg, d0)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice162() (n int, e error) {
-	d0 := int16(p.readU16(p.iBuf[0:2])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d1 := int16(p.readU16(p.iBuf[2:4])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, d0, d1))
+	d0 := int16(p.readU16(p.iBuf[0:2]))
+	d1 := int16(p.readU16(p.iBuf[2:4]))
+	n = p.sprint(p.trice.Strg, d0, d1)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice163() (n int, e error) {
-	d0 := int16(p.readU16(p.iBuf[0:2])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d1 := int16(p.readU16(p.iBuf[2:4])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d2 := int16(p.readU16(p.iBuf[4:6])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d3 := int16(p.readU16(p.iBuf[6:8])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
+	d0 := int16(p.readU16(p.iBuf[0:2]))
+	d1 := int16(p.readU16(p.iBuf[2:4]))
+	d2 := int16(p.readU16(p.iBuf[4:6]))
+	d3 := int16(p.readU16(p.iBuf[6:8]))
 	if 0 != d3 {
 		return p.outOfSync("padding bytes not zero")
 	}
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, d0, d1, d2))
+	n = p.sprint(p.trice.Strg, d0, d1, d2)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice164() (n int, e error) {
-	d0 := int16(p.readU16(p.iBuf[0:2])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d1 := int16(p.readU16(p.iBuf[2:4])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d2 := int16(p.readU16(p.iBuf[4:6])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d3 := int16(p.readU16(p.iBuf[6:8])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, d0, d1, d2, d3))
+	d0 := int16(p.readU16(p.iBuf[0:2]))
+	d1 := int16(p.readU16(p.iBuf[2:4]))
+	d2 := int16(p.readU16(p.iBuf[4:6]))
+	d3 := int16(p.readU16(p.iBuf[6:8]))
+	n = p.sprint(p.trice.Strg, d0, d1, d2, d3)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice321() (n int, e error) {
-	d0 := int32(p.readU32(p.iBuf[0:4])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, d0))
+	d0 := int32(p.readU32(p.iBuf[0:4]))
+	n = p.sprint(p.trice.Strg, d0)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice322() (n int, e error) {
-	d0 := int32(p.readU32(p.iBuf[0:4])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d1 := int32(p.readU32(p.iBuf[4:8])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, d0, d1))
+	d0 := int32(p.readU32(p.iBuf[0:4]))
+	d1 := int32(p.readU32(p.iBuf[4:8]))
+	n = p.sprint(p.trice.Strg, d0, d1)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice323() (n int, e error) {
-	d0 := int32(p.readU32(p.iBuf[0:4]))   // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d1 := int32(p.readU32(p.iBuf[4:8]))   // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d2 := int32(p.readU32(p.iBuf[8:12]))  // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d3 := int32(p.readU32(p.iBuf[12:16])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
+	d0 := int32(p.readU32(p.iBuf[0:4]))
+	d1 := int32(p.readU32(p.iBuf[4:8]))
+	d2 := int32(p.readU32(p.iBuf[8:12]))
+	d3 := int32(p.readU32(p.iBuf[12:16]))
 	if 0 != d3 {
 		return p.outOfSync("padding bytes not zero")
 	}
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, d0, d1, d2))
+	n = p.sprint(p.trice.Strg, d0, d1, d2)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice324() (n int, e error) {
-	d0 := int32(p.readU32(p.iBuf[0:4]))   // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d1 := int32(p.readU32(p.iBuf[4:8]))   // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d2 := int32(p.readU32(p.iBuf[8:12]))  // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d3 := int32(p.readU32(p.iBuf[12:16])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, d0, d1, d2, d3))
+	d0 := int32(p.readU32(p.iBuf[0:4]))
+	d1 := int32(p.readU32(p.iBuf[4:8]))
+	d2 := int32(p.readU32(p.iBuf[8:12]))
+	d3 := int32(p.readU32(p.iBuf[12:16]))
+	n = p.sprint(p.trice.Strg, d0, d1, d2, d3)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice641() (n int, e error) {
-	d0 := int64(p.readU64(p.iBuf[0:8])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, d0))
+	d0 := int64(p.readU64(p.iBuf[0:8]))
+	n = p.sprint(p.trice.Strg, d0)
 	p.rub(p.bc)
 	return
 }
 
 func (p *Esc) trice642() (n int, e error) {
-	d0 := int64(p.readU64(p.iBuf[0:8]))  // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	d1 := int64(p.readU64(p.iBuf[8:16])) // to do: parse for %nu, exchange with %nd and use than uint8 instead of int8
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, d0, d1))
+	d0 := int64(p.readU64(p.iBuf[0:8]))
+	d1 := int64(p.readU64(p.iBuf[8:16]))
+	n = p.sprint(p.trice.Strg, d0, d1)
 	p.rub(p.bc)
 	return
 }
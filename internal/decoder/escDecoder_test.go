@@ -3,7 +3,14 @@
 
 package decoder
 
-import "testing"
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/tj/assert"
+)
 
 func TestEsc(t *testing.T) {
 	doTableTest(t, NewEscDecoder, bigEndian, escTestTable)
@@ -155,3 +162,34 @@ var escTestTable = testTable{
 		55, 56, 57, 58, 59, 60, 61, 62, 63, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 32, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 32, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 0}, ` !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_ abcdefghijklmnopqrstuvwxyz{|}~  !"#$%&'()*+,-./0123456789:;<=>? !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_ abcdefghijklmnopqrstuvwxyz{|}~  !"#$%&'()*+,-./0123456789:;<=>`},
 	{[]byte{236, 226, 186, 47, 2, 146, 0, 0}, `MSG: triceFifoMaxDepth = 658, select = 0`},
 }
+
+// frameReader delivers one frame with each Read like an UDP receiver with args "frame".
+type frameReader struct {
+	frames [][]byte
+}
+
+func (p *frameReader) Read(b []byte) (n int, err error) {
+	if 0 == len(p.frames) {
+		return 0, io.EOF
+	}
+	n = copy(b, p.frames[0])
+	p.frames = p.frames[1:]
+	return
+}
+
+func (p *frameReader) Framed() bool {
+	return true
+}
+
+func TestEscFramed(t *testing.T) {
+	lu := tilLookUp(t)
+	in := &frameReader{[][]byte{
+		{236, 225, 254, 144, 48, 57, 236, 225}, // incomplete trice at frame end
+		{236, 225, 254, 144, 48, 57},
+	}}
+	act := readAll(NewEscDecoder(lu, new(sync.RWMutex), in, bigEndian))
+	assert.Equal(t, 3, len(act))
+	assert.Equal(t, `dbg:12345 as 16bit is 0b0011000000111001\n`, act[0])
+	assert.True(t, strings.HasPrefix(act[1], "error:incomplete trice at frame end"))
+	assert.Equal(t, `dbg:12345 as 16bit is 0b0011000000111001\n`, act[2])
+}
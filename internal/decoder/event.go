@@ -0,0 +1,96 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rokath/trice/internal/id"
+)
+
+// TriceEvent is a decoded trice as data. Sinks like filters or exporters can use it instead of parsing the rendered text.
+type TriceEvent struct {
+	ID     id.TriceID    // ID is the trice ID. It is also set for a decoder error about an ID, like an unknown ID.
+	Type   string        // Type is the trice type from the ID list like "TRICE16_2". It is empty for sync packets and decoder messages like errors.
+	Strg   string        // Strg is the format string from the ID list.
+	Values []interface{} // Values are the typed argument values as used for rendering, like int16 or uint32, float32 or float64 for TRICE32F and TRICE64F, a string for TRICE_S or a []byte for TRICE_B. It is nil without values.
+	Cycle  int           // Cycle is the cycle counter of the trice or -1 if the encoding has no cycle counter.
+	Offset int64         // Offset is the byte offset of the trice in the input stream.
+	Time   time.Time     // Time is the receive timestamp.
+	Text   string        // Text is the rendered trice as returned by Read. It is empty if nothing was decoded.
+}
+
+// EventDecoder is a Decoder, which delivers also the decoded trice data.
+type EventDecoder interface {
+	Decoder
+
+	// ReadEvent reads the next trice into b like Read and returns it as TriceEvent.
+	ReadEvent(b []byte) (TriceEvent, error)
+}
+
+// ReadEvent reads the next trice into b like Read and returns it as TriceEvent.
+func (p *Flex) ReadEvent(b []byte) (TriceEvent, error) {
+	p.newEvent()
+	n, err := p.Read(b)
+	return p.event(b[:n]), err
+}
+
+// ReadEvent reads the next trice into b like Read and returns it as TriceEvent.
+func (p *Esc) ReadEvent(b []byte) (TriceEvent, error) {
+	p.newEvent()
+	n, err := p.Read(b)
+	return p.event(b[:n]), err
+}
+
+// newEvent starts collecting the data for the next trice, which starts at iBuf[0].
+func (p *decoderData) newEvent() {
+	p.ev = TriceEvent{Cycle: -1, Offset: p.pos}
+	p.rendered = false
+}
+
+// eventTrice records the trice ID and, if found in the ID list, the trice type and format string.
+func (p *decoderData) eventTrice(triceID id.TriceID, found bool) {
+	p.ev.ID = triceID
+	if found {
+		p.ev.Type, p.ev.Strg = p.trice.Type, p.trice.Strg
+	} else {
+		p.stats.unknownID(triceID)
+	}
+}
+
+// event returns the collected trice data with the rendered text b.
+// Decoder messages like errors get no trice type and format string.
+func (p *decoderData) event(b []byte) TriceEvent {
+	e := p.ev
+	e.Text = string(b)
+	e.Time = time.Now()
+	if !p.rendered {
+		e.Type, e.Strg, e.Cycle = "", "", -1
+	}
+	return e
+}
+
+// sprint renders the trice values v with the C printf format string f into the read buffer and keeps v for ReadEvent.
+// The values of the float trice types are float32 or float64, otherwise they are converted according to the format specifiers,
+// see cformat.Parse.
+func (p *decoderData) sprint(f string, v ...interface{}) int {
+	c := p.cFormat(f)
+	if p.float {
+		v = floatValues(v)
+	}
+	v = values(c, v)
+	return p.render(fmt.Sprintf(c.GoFmt, v...), v)
+}
+
+// render writes the rendered trice s into the read buffer and keeps its values v for ReadEvent. p.prefix is written in front.
+// It counts the trice for the decoder statistics.
+func (p *decoderData) render(s string, v []interface{}) int {
+	p.ev.Values = v
+	p.rendered = true
+	p.stats.trice(p.ev.ID, p.ev.Strg)
+	s = p.prefix + s
+	p.prefix = ""
+	return copy(p.b, s)
+}
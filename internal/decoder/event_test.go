@@ -0,0 +1,81 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rokath/trice/internal/emitter"
+	"github.com/rokath/trice/internal/id"
+	"github.com/tj/assert"
+)
+
+// readEvents returns all events with text from dec.
+func readEvents(t *testing.T, dec Decoder) (events []TriceEvent) {
+	ed, ok := dec.(EventDecoder)
+	assert.True(t, ok)
+	b := make([]byte, defaultSize)
+	for {
+		e, err := ed.ReadEvent(b)
+		if "" != e.Text {
+			assert.False(t, e.Time.IsZero())
+			events = append(events, e)
+		}
+		if io.EOF == err && "" == e.Text {
+			return
+		}
+	}
+}
+
+func TestReadEventFlex(t *testing.T) {
+	lu := tilLookUp(t)
+	in := []byte{1, 124, 227, 255, 0, 0, 4, 0} // TRICE16_2
+	in = append(in, 239, 205, 171, 137)        // sync packet
+	in = append(in, 232, 253, 208, 52)         // Trice16_1
+	in = append(in, 1, 2, 3, 4)                // unknown ID
+	dec := NewFlexDecoder(lu, new(sync.RWMutex), bytes.NewReader(in), littleEndian)
+	e := readEvents(t, dec)
+	assert.Equal(t, 4, len(e))
+
+	assert.Equal(t, id.TriceID(1047663), e[0].ID)
+	assert.Equal(t, "TRICE16_2", e[0].Type)
+	assert.Equal(t, `MSG: triceFifoMaxDepth = %d, select = %d\n`, e[0].Strg)
+	assert.Equal(t, []interface{}{int16(4), int16(0)}, e[0].Values)
+	assert.Equal(t, 1, e[0].Cycle)
+	assert.Equal(t, int64(0), e[0].Offset)
+	assert.Equal(t, `MSG: triceFifoMaxDepth = 4, select = 0\n`, e[0].Text)
+
+	assert.Equal(t, TriceEvent{Cycle: -1, Offset: 8, Time: e[1].Time, Text: emitter.SyncPacketPattern}, e[1])
+
+	assert.Equal(t, id.TriceID(13520), e[2].ID)
+	assert.Equal(t, "Trice16_1", e[2].Type)
+	assert.Equal(t, []interface{}{uint16(65000)}, e[2].Values)
+	assert.Equal(t, -1, e[2].Cycle) // short trices have no cycle counter
+	assert.Equal(t, int64(12), e[2].Offset)
+	assert.Equal(t, `rd: Trice16_1 65000\n`, e[2].Text)
+
+	assert.Equal(t, id.TriceID(1027), e[3].ID) // unknown ID
+	assert.Equal(t, "", e[3].Type)
+	assert.Equal(t, int64(16), e[3].Offset)
+	assert.True(t, strings.HasPrefix(e[3].Text, "error: unknown triceID  1027"))
+}
+
+func TestReadEventEsc(t *testing.T) {
+	lu := tilLookUp(t)
+	in := []byte{236, 225, 254, 144, 48, 57, 236, 225, 254, 144, 48, 57}
+	dec := NewEscDecoder(lu, new(sync.RWMutex), bytes.NewReader(in), bigEndian)
+	e := readEvents(t, dec)
+	assert.Equal(t, 2, len(e))
+	for i, x := range e {
+		assert.Equal(t, id.TriceID(65168), x.ID)
+		assert.Equal(t, []interface{}{int16(12345)}, x.Values)
+		assert.Equal(t, -1, x.Cycle)
+		assert.Equal(t, int64(6*i), x.Offset)
+		assert.Equal(t, `dbg:12345 as 16bit is 0b0011000000111001\n`, x.Text)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"io"
+	"sync"
+
+	"github.com/rokath/trice/internal/id"
+)
+
+// NewFlexCRCDecoder provides a decoder instance for the flexCRC encoding.
+//
+// The flexCRC encoding is the flex encoding with a 4-byte trailer word after each trice, sync packets have no trailer.
+// The trailer of a short sub-encoding trice is `00000000 00000000 00000000 cccccccc` with the CRC-8 (polynomial 0x07, init 0x00)
+// of the 4 trice bytes. The trailer of a medium or long sub-encoding trice is `00000000 00000000 CCCCCCCC CCCCCCCC` with the
+// CRC-16/CCITT-FALSE (polynomial 0x1021, init 0xFFFF) of all trice bytes including a long count and padding bytes.
+// The trailer is transmitted in the trice endianness. A trice with a not matching trailer is reported as error and not displayed.
+func NewFlexCRCDecoder(lut id.TriceIDLookUp, m *sync.RWMutex, in io.Reader, endian bool) Decoder {
+	p := NewFlexDecoder(lut, m, in, endian).(*Flex)
+	p.crc = true
+	return p
+}
+
+// crcOk returns true if the CRC trailer following the size trice bytes in the interpret buffer matches.
+// bits is 8 for the CRC-8 of short trices and 16 for the CRC-16 of medium and long trices.
+func (p *Flex) crcOk(size, bits int) bool {
+	t := p.readU32(p.iBuf[size : size+4])
+	c := uint32(crc16(p.iBuf[:size]))
+	if 8 == bits {
+		c = uint32(crc8(p.iBuf[:size]))
+	}
+	if t != c {
+		return false
+	}
+	p.trailer = 4
+	return true
+}
+
+// crcError reports a not matching CRC trailer. The statistics count it as decoder error.
+func (p *Flex) crcError() (n int, err error) {
+	return p.outOfSync("CRC mismatch for triceID", LastTriceID)
+}
+
+// crc8 returns the CRC-8 of b with polynomial 0x07 and init value 0x00.
+func crc8(b []byte) (c uint8) {
+	for _, x := range b {
+		c ^= x
+		for i := 0; i < 8; i++ {
+			if 0 != c&0x80 {
+				c = c<<1 ^ 0x07
+			} else {
+				c <<= 1
+			}
+		}
+	}
+	return
+}
+
+// crc16 returns the CRC-16/CCITT-FALSE of b with polynomial 0x1021 and init value 0xFFFF.
+func crc16(b []byte) uint16 {
+	c := uint16(0xffff)
+	for _, x := range b {
+		c ^= uint16(x) << 8
+		for i := 0; i < 8; i++ {
+			if 0 != c&0x8000 {
+				c = c<<1 ^ 0x1021
+			} else {
+				c <<= 1
+			}
+		}
+	}
+	return c
+}
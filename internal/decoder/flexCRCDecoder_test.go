@@ -0,0 +1,67 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rokath/trice/internal/emitter"
+	"github.com/tj/assert"
+)
+
+func TestCRC(t *testing.T) {
+	assert.Equal(t, uint8(0xf4), crc8([]byte("123456789")))
+	assert.Equal(t, uint16(0x29b1), crc16([]byte("123456789")))
+}
+
+func TestFlexCRCL(t *testing.T) {
+	doTableTest(t, NewFlexCRCDecoder, littleEndian, flexCRCTableL)
+}
+
+func TestFlexCRC(t *testing.T) {
+	doTableTest(t, NewFlexCRCDecoder, bigEndian, flexCRCTableB)
+}
+
+func TestFlexCRCMismatch(t *testing.T) {
+	defer withStats()()
+	lu := tilLookUp(t)
+	in := []byte{1, 124, 227, 255, 0, 0, 4, 0, 84, 115, 0, 0}
+	in = append(in, 2, 124, 227, 255, 1, 0, 9, 0, 248, 136, 0, 0) // select value 8 changed to 9
+	in = append(in, 239, 205, 171, 137)                           // sync packet
+	in = append(in, 3, 124, 227, 255, 2, 0, 8, 0, 247, 84, 0, 0)
+	dec := NewFlexCRCDecoder(lu, new(sync.RWMutex), bytes.NewReader(in), littleEndian)
+	var act []string
+	for _, s := range readAll(dec) {
+		if emitter.SyncPacketPattern != s {
+			act = append(act, s)
+		}
+	}
+	assert.Equal(t, `MSG: triceFifoMaxDepth = 4, select = 0\n`, act[0])
+	assert.True(t, strings.HasPrefix(act[1], "error: CRC mismatch for triceID 1047663"))
+	for _, s := range act[1 : len(act)-1] {
+		assert.True(t, strings.HasPrefix(s, "error:"), s) // no trice with wrong values
+	}
+	assert.Equal(t, `MSG: triceFifoMaxDepth = 8, select = 2\n`, act[len(act)-1])
+	assert.True(t, 0 < sessionStats.outOfSync["CRC mismatch for triceID"])
+}
+
+var flexCRCTableL = testTable{ // little endian
+	{[]byte{0, 0, 11, 105, 143, 0, 0, 0}, `wr: Trice0    short`},
+	{[]byte{232, 253, 208, 52, 31, 0, 0, 0}, `rd: Trice16_1 65000`},
+	{[]byte{205, 171, 17, 101, 142, 0, 0, 0}, `diag: Trice8_2  0x-55, 0x-33`},
+	{[]byte{1, 124, 227, 255, 0, 0, 4, 0, 84, 115, 0, 0}, `MSG: triceFifoMaxDepth = 4, select = 0`},
+	{[]byte{2, 124, 227, 255, 1, 0, 8, 0, 248, 136, 0, 0}, `MSG: triceFifoMaxDepth = 8, select = 1`},
+	{[]byte{3, 124, 227, 255, 2, 0, 8, 0, 247, 84, 0, 0}, `MSG: triceFifoMaxDepth = 8, select = 2`},
+	{[]byte{4, 183, 194, 134, 247, 255, 8, 0, 0, 0, 0, 0, 109, 0, 0, 0, 69, 16, 0, 0}, `rd:trice64 109`},
+}
+
+var flexCRCTableB = testTable{ // big endian
+	{[]byte{255, 227, 124, 1, 0, 4, 0, 0, 0, 0, 93, 254}, `MSG: triceFifoMaxDepth = 4, select = 0`},
+	{[]byte{255, 227, 124, 2, 0, 8, 0, 1, 0, 0, 214, 108}, `MSG: triceFifoMaxDepth = 8, select = 1`},
+	{[]byte{137, 171, 205, 239, 255, 227, 124, 3, 0, 8, 0, 2, 0, 0, 76, 94}, `MSG: triceFifoMaxDepth = 8, select = 2`}, // sync packet without trailer
+	{[]byte{134, 194, 183, 4, 0, 8, 255, 247, 0, 0, 0, 0, 0, 0, 0, 109, 0, 0, 63, 172}, `rd:trice64 109`},
+}
@@ -22,9 +22,16 @@ type Flex struct {
 	d0, d1, d2, d3 uint32 // read raw data
 	cycle          int
 	cycleErrorFlag bool
-	sCount         int    // for TRICE_S adaption
-	rBuf           []byte // unprocessed (possibly encrypted) bytes for reading
-	offset         int    // points to the not yet decrypted bytes inside rBuf in case of encryption
+	cycleKnown     bool           // cycle is from a decoded trice
+	unknown        []unknownFrame // kept trices with unknown ID for re-rendering
+	lutLen         int            // ID list size when the last unknown ID trice was kept
+	longCount      bool           // the actual header is followed by a long count
+	pack2          bool           // pack2 encoding: no mode bit, 20-bit ID and 4-bit count
+	crc            bool           // flexCRC encoding: each trice is followed by a CRC trailer
+	trailer        int            // checked CRC trailer size of the actual trice
+	sCount         int            // for TRICE_S and TRICE_B adaption
+	rBuf           []byte         // unprocessed (possibly encrypted) bytes for reading
+	offset         int            // points to the not yet decrypted bytes inside rBuf in case of encryption
 }
 
 // NewFlexDecoder provides an decoder instance.
@@ -43,6 +50,8 @@ func NewFlexDecoder(lut id.TriceIDLookUp, m *sync.RWMutex, in io.Reader, endian
 	p.innerReadInterval = 100 * time.Millisecond
 	p.cycleErrorFlag = true // avoid cycle error message @ start
 	p.inSync = true
+	p.framed = isFramed(in)
+	p.stats = decoderStats()
 	return p
 }
 
@@ -54,6 +63,14 @@ func NewFlexDecoder(lut id.TriceIDLookUp, m *sync.RWMutex, in io.Reader, endian
 // A line can contain several trice strings.
 func (p *Flex) Read(b []byte) (n int, err error) {
 	p.b = b
+	if n = p.rerender(); 0 < n {
+		return
+	}
+	if p.framed && 0 < len(p.iBuf) { // interpret the frame rest before reading the next frame
+		cnt := len(p.iBuf)
+		n, err = p.interpret()
+		return p.frameEnd(cnt, n, err)
+	}
 	if time.Since(p.lastInnerRead) > p.innerReadInterval { // poll inner reader
 		var m int
 		if Verbose { // time measure
@@ -87,20 +104,39 @@ func (p *Flex) Read(b []byte) (n int, err error) {
 			p.offset += m                            // adjust offset
 		}
 		p.rubbed = 0 // reset
+		if p.framed && 0 < m {
+			p.aligned = true // a new frame starts with a trice
+		}
 		if nil != err && io.EOF != err {
 			return
 		}
 	}
 
+	if len(p.iBuf) < 4 {
+		return // wait, err could be io.EOF
+	}
+	cnt := len(p.iBuf)
+	n, err = p.interpret()
+	return p.frameEnd(cnt, n, err)
+}
+
+// interpret returns one trice string from the interpret buffer or nothing, if more bytes are needed.
+func (p *Flex) interpret() (n int, err error) {
 	// Even err could be io.EOF some valid data possibly in p.syncBuffer.
 	// In case of file input (JLINK usage) a plug off is not detectable here.
 	if len(p.iBuf) < 4 {
 		return // wait
 	}
 	head := p.readU32(p.iBuf[0:4])
+	p.longCount = false
+	p.trailer = 0
+	p.prefix = ""
 	if 0x89abcdef == head {
 		return p.syncTrice()
 	}
+	if p.pack2 {
+		return p.pack2Encoding(head)
+	}
 	if 0 == 0x80000000&head { // small sub-encoding
 		return p.smallSubEncoding(head)
 	}
@@ -111,17 +147,27 @@ func (p *Flex) checkLookUpTable(triceID id.TriceID) (ok bool) {
 	p.lutMutex.RLock()
 	p.trice, ok = p.lut[triceID] // check lookup table
 	p.lutMutex.RUnlock()
+	p.eventTrice(triceID, ok)
 	return
 }
 
 func (p *Flex) smallSubEncoding(head uint32) (n int, err error) {
 	LastTriceID = id.TriceID(head >> 16) // bits 30...16 are the 15-bit ID
+	if p.crc {
+		if len(p.iBuf) < 8 {
+			return // wait
+		}
+		if !p.crcOk(4, 8) {
+			return p.crcError()
+		}
+	}
 	ok := p.checkLookUpTable(LastTriceID)
 	if !ok {
-		return p.outOfSync(fmt.Sprintf("unknown triceID %5d", LastTriceID))
+		return p.unknownTrice(0, -1)
 	}
 	p.d0 = 0xffff & head
 	p.upperCaseTriceType = p.trice.Type // no conversion here, but a copy is needed
+	p.float = false
 	switch p.trice.Type {
 	case "Trice0", "Trice0i":
 		return p.sprintTrice(0)
@@ -135,6 +181,13 @@ func (p *Flex) mediumAndLongSubEncoding(head uint32) (n int, err error) {
 	LastTriceID = id.TriceID(head >> (31 - 20)) // bits 30...11 are the 20-bit ID
 	count := int((0x00000700 & head) >> 8)      // this nibble is the 3-bit count
 	cycle := int(0x000000ff & head)             // least significant byte is the cycle
+	// TRICE_LONGCOUNT(n), values 0-4 short counts, 0x7 is long count and 0x5 & 0x6 are reserved.
+	return p.countedTrice(count, 0x7 == count, cycle)
+}
+
+// countedTrice interprets the trice with ID LastTriceID, byte count and cycle counter.
+// If longCount is true, count is ignored and the long count following the header is used.
+func (p *Flex) countedTrice(count int, longCount bool, cycle int) (n int, err error) {
 	var cycleWarning string
 	if cycle != 0xff&(p.cycle+1) { // lost trices or out of sync
 		if !p.cycleErrorFlag {
@@ -143,7 +196,7 @@ func (p *Flex) mediumAndLongSubEncoding(head uint32) (n int, err error) {
 		}
 	}
 
-	if 0x7 == count { // TRICE_LONGCOUNT(n), values 0-4 short counts, 0x7 is long count and 0x5 & 0x6 are reserved.
+	if longCount {
 		if len(p.iBuf) < 8 {
 			return // wait
 		}
@@ -151,30 +204,45 @@ func (p *Flex) mediumAndLongSubEncoding(head uint32) (n int, err error) {
 		count16 := int16(countTransfer >> 16)
 		count16invers := int16(countTransfer)
 		if count16 != ^count16invers {
-			return p.outOfSync(fmt.Sprintf("invalid countTransfer %08x", countTransfer))
+			return p.outOfSync("invalid countTransfer", fmt.Sprintf("%08x", countTransfer))
+		}
+		count = int(uint16(count16))
+	}
+	p.longCount = longCount
+	if p.crc {
+		size := p.triceSize(count)
+		if len(p.iBuf) < size+4 {
+			return // wait
+		}
+		if !p.crcOk(size, 16) {
+			return p.crcError()
 		}
-		count = int(count16)
 	}
 
 	ok := p.checkLookUpTable(LastTriceID)
 	if !ok {
-		return p.outOfSync(fmt.Sprintf("unknown triceID %5d", LastTriceID))
+		return p.unknownTrice(count, cycle)
 	}
-	p.upperCaseTriceType = strings.ToUpper(p.trice.Type) // for trice* too
+	p.setTriceType()
 	if !p.bytesCountOk(count) {
-		return p.outOfSync(fmt.Sprintf("unecpected byteCount, it is not %d", count))
+		return p.outOfSync("unexpected byteCount, it is not", count)
 	}
 	if !p.isTriceComplete(count) {
 		return // try later again
 	}
 	if !p.readDataAndCheckPaddingBytes(count) {
-		return p.outOfSync(fmt.Sprintf("error:padding bytes not zero"))
+		return p.outOfSync("padding bytes not zero")
 	}
 
 	// ID and count are ok
+	if p.cycleKnown { // The gap is an estimation, because the cycle counter wraps at 256.
+		p.stats.lostTrices(0xff & (cycle - (p.cycle + 1)))
+	}
+	p.cycleKnown = true
 	p.cycleErrorFlag = false
 	p.cycle = cycle // Set cycle for checking next trice here because all checks passed.
-	p.trice.Strg = cycleWarning + p.trice.Strg
+	p.ev.Cycle = cycle
+	p.prefix = cycleWarning
 	return p.sprintTrice(count)
 }
 
@@ -183,7 +251,7 @@ func (p *Flex) mediumAndLongSubEncoding(head uint32) (n int, err error) {
 func (p *Flex) readDataAndCheckPaddingBytes(cnt int) (ok bool) {
 	b := make([]byte, cnt+3+8) // max 3 more plus head plus possible long count
 	copy(b, p.iBuf)
-	if cnt > 4 {
+	if p.longCount {
 		b = append(b[0:4], b[8:]...) // remove long count in copy
 	}
 	tt := strings.TrimRight(p.upperCaseTriceType, "I")
@@ -202,7 +270,7 @@ func (p *Flex) readDataAndCheckPaddingBytes(cnt int) (ok bool) {
 	case "TRICE8_4", "TRICE16_2", "TRICE32_1":
 		p.d0 = p.readU32(b[4:8])
 		return true // no padding bytes
-	case "TRICE_S":
+	case "TRICE_S", "TRICE_B":
 		x := 3 & cnt
 		switch x {
 		case 0:
@@ -243,16 +311,18 @@ func (p *Flex) readDataAndCheckPaddingBytes(cnt int) (ok bool) {
 
 // isTriceComplete returns true if triceType payload is complete.
 func (p *Flex) isTriceComplete(cnt int) bool {
-	longCountBytes := 0
-	if cnt > 4 {
-		longCountBytes = 4
-	}
-	cnt += 3
-	cnt &= ^3
-	if len(p.iBuf) < 4+longCountBytes+cnt {
-		return false
+	return p.triceSize(cnt) <= len(p.iBuf)
+}
+
+// triceSize returns the byte count of a medium or long trice with cnt data bytes without CRC trailer.
+func (p *Flex) triceSize(cnt int) int {
+	n := cnt + 3
+	n &= ^3 // only 4-byte groups
+	n += 4  // add header size
+	if p.longCount {
+		n += 4 // add long count
 	}
-	return true
+	return n
 }
 
 // bytesCountOk returns true if the transmitted count information matches the expected count.
@@ -289,7 +359,7 @@ func (p *Flex) expectedByteCount() int {
 		return 12
 	case "TRICE32_4", "TRICE64_2":
 		return 16
-	case "TRICE_S":
+	case "TRICE_S", "TRICE_B":
 		return p.sCount // cannot check count
 	default:
 		return -1 // unknown trice type
@@ -327,6 +397,7 @@ var flexSel = []flexSelector{
 	{"Trice8_2", (*Flex).trice82s},
 	{"Trice16_1", (*Flex).trice161s},
 	{"TRICE_S", (*Flex).triceSCount},
+	{"TRICE_B", (*Flex).triceB},
 }
 
 // sprintTrice generates the trice string.
@@ -342,7 +413,7 @@ func (p *Flex) sprintTrice(cnt int) (n int, e error) {
 	if "TRICE_S" == p.upperCaseTriceType {
 		return p.triceS(cnt)
 	}
-	return p.outOfSync(fmt.Sprintf("Unexpected trice.Type %s", p.trice.Type))
+	return p.outOfSync("Unexpected trice.Type", p.trice.Type)
 }
 
 func (p *Flex) triceSCount() (n int, e error) {
@@ -351,16 +422,29 @@ func (p *Flex) triceSCount() (n int, e error) {
 
 func (p *Flex) triceS(cnt int) (n int, e error) {
 	o := 4
-	if cnt > 4 {
+	if p.longCount {
 		o += 4
 	}
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg, string(p.iBuf[o:o+cnt])))
+	n = p.sprint(p.trice.Strg, string(p.iBuf[o:o+cnt]))
 	p.rub4(cnt)
 	return
 }
 
+// triceB renders the p.sCount bytes of a TRICE_B, see bufferText.
+func (p *Flex) triceB() (n int, e error) {
+	o := 4
+	if p.longCount {
+		o += 4
+	}
+	b := make([]byte, p.sCount)
+	copy(b, p.iBuf[o:])
+	n = p.render(bufferText(p.trice.Strg, b), []interface{}{b})
+	p.rub4(p.sCount)
+	return
+}
+
 func (p *Flex) trice0() (n int, e error) {
-	n = copy(p.b, fmt.Sprintf(p.trice.Strg))
+	n = p.sprint(p.trice.Strg)
 	p.rub4(0)
 	return
 }
@@ -368,8 +452,8 @@ func (p *Flex) trice0() (n int, e error) {
 func (p *Flex) trice81x() (n int, e error) {
 	d := make([]uint64, 1)
 	split1Byte(d, p.d0)
-	s, b, e := p.uReplace8(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0]))
+	b := signedValues(8, d)
+	n = p.sprint(p.trice.Strg, b[0])
 	return
 }
 
@@ -388,8 +472,8 @@ func (p *Flex) trice81() (n int, e error) {
 func (p *Flex) trice82x() (n int, e error) {
 	d := make([]uint64, 2)
 	split2Bytes(d, p.d0)
-	s, b, e := p.uReplace8(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0], b[1]))
+	b := signedValues(8, d)
+	n = p.sprint(p.trice.Strg, b[0], b[1])
 	return
 }
 
@@ -408,8 +492,8 @@ func (p *Flex) trice82() (n int, e error) {
 func (p *Flex) trice83() (n int, e error) {
 	d := make([]uint64, 3)
 	split3Bytes(d, p.d0)
-	s, b, e := p.uReplace8(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0], b[1], b[2]))
+	b := signedValues(8, d)
+	n = p.sprint(p.trice.Strg, b[0], b[1], b[2])
 	p.rub4(3)
 	return
 }
@@ -456,8 +540,8 @@ func split8Bytes(d []uint64, u0, u1 uint32) {
 func (p *Flex) trice84() (n int, e error) {
 	d := make([]uint64, 4)
 	split4Bytes(d, p.d0)
-	s, b, e := p.uReplace8(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0], b[1], b[2], b[3]))
+	b := signedValues(8, d)
+	n = p.sprint(p.trice.Strg, b[0], b[1], b[2], b[3])
 	p.rub4(4)
 	return
 }
@@ -465,8 +549,8 @@ func (p *Flex) trice84() (n int, e error) {
 func (p *Flex) trice85() (n int, e error) {
 	d := make([]uint64, 5)
 	split5Bytes(d, p.d0, p.d1)
-	s, b, e := p.uReplace8(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0], b[1], b[2], b[3], b[4]))
+	b := signedValues(8, d)
+	n = p.sprint(p.trice.Strg, b[0], b[1], b[2], b[3], b[4])
 	p.rub4(5)
 	return
 }
@@ -474,8 +558,8 @@ func (p *Flex) trice85() (n int, e error) {
 func (p *Flex) trice86() (n int, e error) {
 	d := make([]uint64, 6)
 	split6Bytes(d, p.d0, p.d1)
-	s, b, e := p.uReplace8(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0], b[1], b[2], b[3], b[4], b[5]))
+	b := signedValues(8, d)
+	n = p.sprint(p.trice.Strg, b[0], b[1], b[2], b[3], b[4], b[5])
 	p.rub4(6)
 	return
 }
@@ -483,8 +567,8 @@ func (p *Flex) trice86() (n int, e error) {
 func (p *Flex) trice87() (n int, e error) {
 	d := make([]uint64, 7)
 	split7Bytes(d, p.d0, p.d1)
-	s, b, e := p.uReplace8(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0], b[1], b[2], b[3], b[4], b[5], b[6]))
+	b := signedValues(8, d)
+	n = p.sprint(p.trice.Strg, b[0], b[1], b[2], b[3], b[4], b[5], b[6])
 	p.rub4(7)
 	return
 }
@@ -492,8 +576,8 @@ func (p *Flex) trice87() (n int, e error) {
 func (p *Flex) trice88() (n int, e error) {
 	d := make([]uint64, 8)
 	split8Bytes(d, p.d0, p.d1)
-	s, b, e := p.uReplace8(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0], b[1], b[2], b[3], b[4], b[5], b[6], b[7]))
+	b := signedValues(8, d)
+	n = p.sprint(p.trice.Strg, b[0], b[1], b[2], b[3], b[4], b[5], b[6], b[7])
 	p.rub4(8)
 	return
 }
@@ -510,8 +594,8 @@ func split2Val16(d []uint64, u uint32) {
 func (p *Flex) trice161x() (n int, e error) {
 	d := make([]uint64, 1)
 	split1Val16(d, p.d0)
-	s, b, e := p.uReplace16(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0]))
+	b := signedValues(16, d)
+	n = p.sprint(p.trice.Strg, b[0])
 	return
 }
 
@@ -531,8 +615,8 @@ func (p *Flex) trice162() (n int, e error) {
 	d := make([]uint64, 2)
 	d[0] = uint64(0xFFFF & (p.d0 >> 16))
 	d[1] = uint64(0xFFFF & p.d0)
-	s, b, e := p.uReplace16(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0], b[1]))
+	b := signedValues(16, d)
+	n = p.sprint(p.trice.Strg, b[0], b[1])
 	p.rub4(4)
 	return
 }
@@ -542,8 +626,8 @@ func (p *Flex) trice163() (n int, e error) {
 	d[0] = uint64(0xFFFF & (p.d0 >> 16))
 	d[1] = uint64(0xFFFF & p.d0)
 	d[2] = uint64(0xFFFF & p.d1)
-	s, b, e := p.uReplace16(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0], b[1], b[2]))
+	b := signedValues(16, d)
+	n = p.sprint(p.trice.Strg, b[0], b[1], b[2])
 	p.rub4(6)
 	return
 }
@@ -554,8 +638,8 @@ func (p *Flex) trice164() (n int, e error) {
 	d[1] = uint64(0xFFFF & p.d0)
 	d[2] = uint64(0xFFFF & (p.d1 >> 16))
 	d[3] = uint64(0xFFFF & p.d1)
-	s, b, e := p.uReplace16(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0], b[1], b[2], b[3]))
+	b := signedValues(16, d)
+	n = p.sprint(p.trice.Strg, b[0], b[1], b[2], b[3])
 	p.rub4(8)
 	return
 }
@@ -563,8 +647,8 @@ func (p *Flex) trice164() (n int, e error) {
 func (p *Flex) trice321() (n int, e error) {
 	d := make([]uint64, 1)
 	d[0] = uint64(p.d0)
-	s, b, e := p.uReplace32(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0]))
+	b := signedValues(32, d)
+	n = p.sprint(p.trice.Strg, b[0])
 	p.rub4(4)
 	return
 }
@@ -573,8 +657,8 @@ func (p *Flex) trice322() (n int, e error) {
 	d := make([]uint64, 2)
 	d[0] = uint64(p.d0)
 	d[1] = uint64(p.d1)
-	s, b, e := p.uReplace32(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0], b[1]))
+	b := signedValues(32, d)
+	n = p.sprint(p.trice.Strg, b[0], b[1])
 	p.rub4(8)
 	return
 }
@@ -584,8 +668,8 @@ func (p *Flex) trice323() (n int, e error) {
 	d[0] = uint64(p.d0)
 	d[1] = uint64(p.d1)
 	d[2] = uint64(p.d2)
-	s, b, e := p.uReplace32(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0], b[1], b[2]))
+	b := signedValues(32, d)
+	n = p.sprint(p.trice.Strg, b[0], b[1], b[2])
 	p.rub4(12)
 	return
 }
@@ -596,8 +680,8 @@ func (p *Flex) trice324() (n int, e error) {
 	d[1] = uint64(p.d1)
 	d[2] = uint64(p.d2)
 	d[3] = uint64(p.d3)
-	s, b, e := p.uReplace32(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0], b[1], b[2], b[3]))
+	b := signedValues(32, d)
+	n = p.sprint(p.trice.Strg, b[0], b[1], b[2], b[3])
 	p.rub4(16)
 	return
 }
@@ -605,8 +689,8 @@ func (p *Flex) trice324() (n int, e error) {
 func (p *Flex) trice641() (n int, e error) {
 	d := make([]uint64, 1)
 	d[0] = (uint64(p.d0) << 32) | uint64(p.d1)
-	s, b, e := p.uReplace64(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0]))
+	b := signedValues(64, d)
+	n = p.sprint(p.trice.Strg, b[0])
 	p.rub4(8)
 	return
 }
@@ -615,93 +699,30 @@ func (p *Flex) trice642() (n int, e error) {
 	d := make([]uint64, 2)
 	d[0] = (uint64(p.d0) << 32) | uint64(p.d1)
 	d[1] = (uint64(p.d2) << 32) | uint64(p.d3)
-	s, b, e := p.uReplace64(d)
-	n = copy(p.b, fmt.Sprintf(s, b[0], b[1]))
+	b := signedValues(64, d)
+	n = p.sprint(p.trice.Strg, b[0], b[1])
 	p.rub4(16)
 	return
 }
 
 func (p *Flex) syncTrice() (n int, e error) {
 	n = copy(p.b, p.syncPacket)
+	p.stats.sync()
 	p.rub4(0)
 	return
 }
 
-// uReplace8 takes parameter values in d and returns them in b as uint8 or int8 according to %nu occurrences in format string.
-// It also returns the modified formatstring with replacments %nu -> %nd.
-func (p *Flex) uReplace8(d []uint64) (s string, b []interface{}, e error) {
-	return p.uReplace(8, d)
-}
-
-// uReplace16 takes parameter values in d and returns them in b as uint16 or int16 according to %nu occurrences in format string.
-// It also returns the modified formatstring with replacments %nu -> %nd.
-func (p *Flex) uReplace16(d []uint64) (s string, b []interface{}, e error) {
-	return p.uReplace(16, d)
-}
-
-// uReplace32 takes parameter values in d and returns them in b as uint32 or int32 according to %nu occurrences in format string.
-// It also returns the modified formatstring with replacments %nu -> %nd.
-func (p *Flex) uReplace32(d []uint64) (s string, b []interface{}, e error) {
-	return p.uReplace(32, d)
-}
-
-// uReplace64 takes parameter values in d and returns them in b as uint64 or int64 according to %nu occurrences in format string.
-// It also returns the modified formatstring with replacments %nu -> %nd.
-func (p *Flex) uReplace64(d []uint64) (s string, b []interface{}, e error) {
-	return p.uReplace(64, d)
-}
-
-// uReplace32 takes parameter values in d and returns them in b as uint32 or int32 according to %nu occurrences in format string.
-// It also returns the modified formatstring with replacments %nu -> %nd.
-func (p *Flex) uReplace(bitWidth int, d []uint64) (s string, b []interface{}, e error) {
-	b = make([]interface{}, len(d))
-	s, u := uReplaceN(p.trice.Strg)
-	if len(u) != len(b) {
-		e = fmt.Errorf("found %d format specifiers in '%s', expecting %d", len(u), p.trice.Strg, len(b))
-		return
-	}
-	for i := range u {
-		if u[i] {
-			switch bitWidth {
-			case 8:
-				b[i] = uint8(d[i])
-			case 16:
-				b[i] = uint16(d[i])
-			case 32:
-				b[i] = uint32(d[i])
-			case 64:
-				b[i] = uint64(d[i])
-			}
-		} else {
-			switch bitWidth {
-			case 8:
-				b[i] = int8(d[i])
-			case 16:
-				b[i] = int16(d[i])
-			case 32:
-				b[i] = int32(d[i])
-			case 64:
-				b[i] = int64(d[i])
-			}
-		}
-	}
-	return
-}
-
 // rub4 removes leading bytes from interpret buffer.
-// It removes 4 bytes header plus data considering encoding
+// It removes 4 bytes header plus data and a checked CRC trailer considering encoding
 func (p *Flex) rub4(count int) {
-	n := count + 3
-	n &= ^3 // only 4-byte groups
-	n += 4  // add header size
-	if count > 4 {
-		n += 4 // add long count
-	}
+	n := p.triceSize(count) + p.trailer
+	p.pos += int64(n)
 	if TestTableMode {
 		p.printTestTableLine(n)
 	}
 	p.iBuf = p.iBuf[n:] // header and data
 	p.rubbed += n
+	p.aligned = true
 }
 
 var testTableVirgin = true
@@ -161,7 +161,7 @@ func Example_rub4() {
 	}
 	for _, x := range table {
 		in := ioutil.NopCloser(bytes.NewBuffer(x.in))
-		dec.setInput(in)
+		dec.SetInput(in)
 		var err error
 		var n int
 		var act string
@@ -1106,3 +1106,40 @@ var tableB = testTable{ // big endian
 	{[]byte{255, 227, 124, 158, 3, 120, 0, 1}, `Id(1047663) MSG: triceFifoMaxDepth = 888, select = 1`},
 	{[]byte{255, 227, 124, 159, 3, 120, 0, 2}, `Id(1047663) MSG: triceFifoMaxDepth = 888, select = 2`},
 }
+
+func TestPlausibility(t *testing.T) {
+	defer func(s string) { Encoding = s }(Encoding)
+	Encoding = "flex"
+	lut := id.TriceIDLookUp{1000: {Type: "Trice16_1", Strg: "v=%d\n"}}
+	m := new(sync.RWMutex)
+	trice := []byte{0x03, 0xe8, 0x00, 0x07} // small sub-encoding, ID 1000, value 7
+	syncPacket := []byte{0x89, 0xab, 0xcd, 0xef}
+
+	var b []byte
+	b = append(b, 0xe8, 0x00, 0x07) // starting inside a trice is no re-sync
+	b = append(b, trice...)
+	b = append(b, syncPacket...)
+	b = append(b, trice...)
+	valid, resyncs := Plausibility(lut, m, b)
+	assert.Equal(t, 3, valid)
+	assert.Equal(t, 0, resyncs)
+
+	b = append(b, 0xff, 0xff) // garbage
+	b = append(b, trice...)
+	b = append(b, 0x00)
+	valid, resyncs = Plausibility(lut, m, b)
+	assert.Equal(t, 4, valid)
+	assert.Equal(t, 1, resyncs)
+
+	valid, resyncs = Plausibility(lut, m, nil)
+	assert.Equal(t, 0, valid)
+	assert.Equal(t, 0, resyncs)
+
+	Encoding = "flexL"
+	b = []byte{1, 124, 227, 255, 0, 0, 4, 0}    // TRICE16_2, cycle 1
+	b = append(b, 2, 4, 18, 143, 7, 0, 8, 0)    // unknown ID 123456 rendered as placeholder, cycle 2
+	b = append(b, 3, 124, 227, 255, 2, 0, 8, 0) // TRICE16_2, cycle 3
+	valid, resyncs = Plausibility(tilLookUp(t), m, b)
+	assert.Equal(t, 2, valid) // the unknown ID is no valid trice
+	assert.Equal(t, 0, resyncs)
+}
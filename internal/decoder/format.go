@@ -0,0 +1,139 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"math"
+
+	"github.com/rokath/trice/internal/id"
+	"github.com/rokath/trice/pkg/cformat"
+)
+
+// values returns v converted according to the format specifiers of f.
+// Values without format specifier stay unchanged, so the Go formatting reports them.
+func values(f *cformat.Format, v []interface{}) []interface{} {
+	for i := range v {
+		if i < len(f.Args) {
+			v[i] = value(f.Args[i], v[i])
+		}
+	}
+	return v
+}
+
+// value returns v converted according to a.
+// Integer values of any signedness are converted, others like strings stay unchanged.
+func value(a cformat.Arg, v interface{}) interface{} {
+	var bits int
+	var u uint64
+	switch x := v.(type) {
+	case int8:
+		bits, u = 8, uint64(uint8(x))
+	case uint8:
+		bits, u = 8, uint64(x)
+	case int16:
+		bits, u = 16, uint64(uint16(x))
+	case uint16:
+		bits, u = 16, uint64(x)
+	case int32:
+		bits, u = 32, uint64(uint32(x))
+	case uint32:
+		bits, u = 32, uint64(x)
+	case int64:
+		bits, u = 64, uint64(x)
+	case uint64:
+		bits, u = 64, x
+	default:
+		return v
+	}
+	if 0 < a.Bits && a.Bits < bits { // like the C cast to char or short
+		bits = a.Bits
+		u &= 1<<bits - 1
+	}
+	switch a.Kind {
+	case cformat.Signed:
+		return signed(bits, u)
+	case cformat.Unsigned, cformat.Pointer:
+		return unsigned(bits, u)
+	case cformat.Hex:
+		if UnsignedHex {
+			return unsigned(bits, u)
+		}
+		return signed(bits, u)
+	case cformat.Float: // Only TRICE32F and TRICE64F values are floats, see floatValues.
+		return v
+	case cformat.Char:
+		return rune(u)
+	case cformat.Star:
+		return int(signExtend(bits, u))
+	}
+	return v
+}
+
+// signExtend returns the bits wide value u as int64.
+func signExtend(bits int, u uint64) int64 {
+	return int64(u<<(64-bits)) >> (64 - bits)
+}
+
+// signed returns the bits wide value u as int8, int16, int32 or int64.
+func signed(bits int, u uint64) interface{} {
+	switch bits {
+	case 8:
+		return int8(u)
+	case 16:
+		return int16(u)
+	case 32:
+		return int32(u)
+	}
+	return int64(u)
+}
+
+// unsigned returns the bits wide value u as uint8, uint16, uint32 or uint64.
+func unsigned(bits int, u uint64) interface{} {
+	switch bits {
+	case 8:
+		return uint8(u)
+	case 16:
+		return uint16(u)
+	case 32:
+		return uint32(u)
+	}
+	return u
+}
+
+// signedValues returns the bits wide values d as int8, int16, int32 or int64, what is the trice type default.
+func signedValues(bits int, d []uint64) []interface{} {
+	v := make([]interface{}, len(d))
+	for i, x := range d {
+		v[i] = signed(bits, x)
+	}
+	return v
+}
+
+// floatValues returns the TRICE32F and TRICE64F values v as float32 and float64.
+// The target transmits the float and double bits.
+func floatValues(v []interface{}) []interface{} {
+	for i, x := range v {
+		switch y := x.(type) {
+		case int32:
+			v[i] = math.Float32frombits(uint32(y))
+		case int64:
+			v[i] = math.Float64frombits(uint64(y))
+		}
+	}
+	return v
+}
+
+// cFormat returns the parsed format string f of the actual trice. The parsed format strings are cached per ID.
+func (p *decoderData) cFormat(f string) *cformat.Format {
+	c, ok := p.formats[p.ev.ID]
+	if ok && c.Strg == f {
+		return c
+	}
+	c = cformat.Parse(f)
+	if nil == p.formats {
+		p.formats = make(map[id.TriceID]*cformat.Format)
+	}
+	p.formats[p.ev.ID] = c
+	return c
+}
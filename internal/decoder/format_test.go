@@ -0,0 +1,105 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/rokath/trice/internal/id"
+	"github.com/rokath/trice/pkg/cformat"
+	"github.com/tj/assert"
+)
+
+func TestCFormatValues(t *testing.T) {
+	for _, x := range []struct {
+		strg string
+		v    []interface{}
+		exp  string
+	}{
+		{"%d %u", []interface{}{int8(-1), int8(-1)}, "-1 255"},
+		{"%5d|%-5u|%05i", []interface{}{int16(-2), int16(-2), int16(42)}, "   -2|65534|00042"},
+		{"%+d % d", []interface{}{int32(7), int32(7)}, "+7  7"},
+		{"%lu %llu", []interface{}{int32(-1), int64(-1)}, "4294967295 18446744073709551615"},
+		{"%hhd %hhu %hd", []interface{}{int32(0x1ff), int32(0x1ff), int32(0x18000)}, "-1 255 -32768"},
+		{"%x %X %#x %o", []interface{}{int16(255), int16(255), int16(255), int16(8)}, "ff FF 0xff 10"},
+		{"%x", []interface{}{int8(-1)}, "-1"}, // signed without -unsignedHex for compatibility
+		{"%*d|%-*d|%.*d", []interface{}{int8(4), int8(1), int8(3), int8(2), int8(3), int8(5)}, "   1|2  |005"},
+		{"%f %.2f %e", []interface{}{float32(1.5), float32(-0.25), float64(12345.678)}, "1.500000 -0.25 1.234568e+04"},
+		{"%g %g %G", []interface{}{float32(0.5), float64(1234567), float64(1e-10)}, "0.5 1.23457e+06 1E-10"},
+		{"%f", []interface{}{int32(math.Float32bits(1.5))}, "%!f(int32=1069547520)"}, // no float bits reinterpretation without TRICE32F
+		{"%c%c%c", []interface{}{int8('a'), int8('b'), int8(-61)}, "abÃ"},
+		{"%p", []interface{}{int32(-0x1000)}, "0xfffff000"},
+		{"%s=%d%%", []interface{}{"x", int16(3)}, "x=3%"},
+		{"%d %d", []interface{}{int8(1)}, "1 %!d(MISSING)"},
+	} {
+		f := cformat.Parse(x.strg)
+		act := fmt.Sprintf(f.GoFmt, values(f, x.v)...)
+		assert.Equal(t, x.exp, act, x.strg)
+	}
+}
+
+func TestCFormatUnsignedHex(t *testing.T) {
+	defer func(u bool) { UnsignedHex = u }(UnsignedHex)
+	UnsignedHex = true
+	f := cformat.Parse("%x %X %o %b %d")
+	assert.Equal(t, "ff FFFF 37777777777 11111111 -1", fmt.Sprintf(f.GoFmt, values(f, []interface{}{int8(-1), int16(-1), int32(-1), int8(-1), int8(-1)})...))
+}
+
+func TestCFormatCache(t *testing.T) {
+	p := &decoderData{}
+	p.ev.ID = 7
+	f := p.cFormat("%u")
+	assert.True(t, f == p.cFormat("%u"))
+	g := p.cFormat("%d") // changed ID list
+	assert.Equal(t, "%d", g.Strg)
+	assert.Equal(t, map[id.TriceID]*cformat.Format{7: g}, p.formats)
+}
+
+func TestFlexCFormat(t *testing.T) {
+	lu := id.TriceIDLookUp{
+		1000: {Type: "Trice16_1", Strg: "v=%hhu\n"},
+		1001: {Type: "TRICE32_1", Strg: "%.2f|\n"}, // float bits need TRICE32F
+		1002: {Type: "TRICE16_2", Strg: "%*d|\n"},
+	}
+	in := []byte{0x03, 0xe8, 0xff, 0xfe}                            // short sub-encoding, ID 1000, value -2
+	in = append(in, 0x80, 0x1f, 0x4c, 0x00, 0x3f, 0xc0, 0x00, 0x00) // ID 1001, value 1.5
+	in = append(in, 0x80, 0x1f, 0x54, 0x01, 0x00, 0x05, 0x00, 0x2a) // ID 1002, values 5 and 42
+	dec := NewFlexDecoder(lu, new(sync.RWMutex), bytes.NewReader(in), bigEndian)
+	assert.Equal(t, []string{"v=254\n", "%!f(int32=1069547520)|\n", "   42|\n"}, readAll(dec))
+}
+
+func TestEscCFormat(t *testing.T) {
+	lu := id.TriceIDLookUp{65168: {Type: "TRICE16_1", Strg: "%u %%"}}
+	in := []byte{236, 225, 254, 144, 255, 254}
+	dec := NewEscDecoder(lu, new(sync.RWMutex), bytes.NewReader(in), bigEndian)
+	assert.Equal(t, []string{"65534 %"}, readAll(dec))
+}
+
+func TestFlexFloat(t *testing.T) {
+	lu := id.TriceIDLookUp{
+		1001: {Type: "TRICE32F_1", Strg: "%.2f|\n"},
+		1003: {Type: "trice64F_1i", Strg: "%g\n"},
+	}
+	in := []byte{0x80, 0x1f, 0x4c, 0x00, 0x3f, 0xc0, 0x00, 0x00}                                  // ID 1001, value 1.5
+	in = append(in, 0x80, 0x1f, 0x5f, 0x01, 0x00, 0x08, 0xff, 0xf7, 0xc0, 0x04, 0, 0, 0, 0, 0, 0) // ID 1003, long count 8, value -2.5
+	dec := NewFlexDecoder(lu, new(sync.RWMutex), bytes.NewReader(in), bigEndian)
+	e := readEvents(t, dec)
+	assert.Equal(t, 2, len(e))
+	assert.Equal(t, "1.50|\n", e[0].Text)
+	assert.Equal(t, []interface{}{float32(1.5)}, e[0].Values)
+	assert.Equal(t, "TRICE32F_1", e[0].Type)
+	assert.Equal(t, "-2.5\n", e[1].Text)
+	assert.Equal(t, []interface{}{float64(-2.5)}, e[1].Values)
+}
+
+func TestEscFloat(t *testing.T) {
+	lu := id.TriceIDLookUp{1000: {Type: "TRICE32F_2", Strg: "%f %e"}}
+	in := []byte{236, 0xe3, 0x03, 0xe8, 0x3f, 0xc0, 0x00, 0x00, 0xbe, 0x00, 0x00, 0x00}
+	dec := NewEscDecoder(lu, new(sync.RWMutex), bytes.NewReader(in), bigEndian)
+	assert.Equal(t, []string{"1.500000 -1.250000e-01"}, readAll(dec))
+}
@@ -0,0 +1,45 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"io"
+	"testing"
+
+	"github.com/rokath/trice/internal/id"
+	"github.com/tj/assert"
+)
+
+// readAll returns the decoded strings from dec until io.EOF.
+func readAll(dec Decoder) (act []string) {
+	b := make([]byte, defaultSize)
+	for {
+		n, err := dec.Read(b)
+		if 0 < n {
+			act = append(act, string(b[:n]))
+		}
+		if io.EOF == err && 0 == n {
+			return
+		}
+	}
+}
+
+// withStats enables the statistics with cleared counters and returns a function restoring Stats and clearing the counters.
+func withStats() func() {
+	s := Stats
+	Stats = true
+	sessionStats.reset()
+	return func() {
+		Stats = s
+		sessionStats.reset()
+	}
+}
+
+// tilLookUp returns the ID list til with the format specifier counts.
+func tilLookUp(t *testing.T) id.TriceIDLookUp {
+	lu := make(id.TriceIDLookUp)
+	assert.Nil(t, lu.FromJSON([]byte(til)))
+	lu.AddFmtCount()
+	return lu
+}
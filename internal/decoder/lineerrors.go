@@ -0,0 +1,74 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rokath/trice/internal/com"
+	"github.com/rokath/trice/internal/receiver"
+)
+
+var (
+	// lineErrorInterval is the max time between two serial line error checks while receiving.
+	lineErrorInterval = time.Second
+
+	// lineErrorWindow is the time after a serial line error, in which a re-sync is assigned to it.
+	lineErrorWindow = time.Second
+
+	// lineErrors returns the serial line error counters. It is a variable for testing.
+	lineErrors = receiver.LineErrors
+)
+
+// lineErrorWatch checks the serial line error counters and correlates them with the decoder re-syncs.
+// It counts the re-syncs and line errors in the session statistics independent of Stats, because its messages show the re-sync counts.
+// A re-sync is a sequence of not decodable bytes. Re-syncs without line errors point to an encoding or til.json mismatch rather than to an electrical problem.
+type lineErrorWatch struct {
+	last      com.LineErrors // counters at last check
+	valid     bool           // last is valid
+	lastCheck time.Time      // time of last check
+	lastError time.Time      // time of last detected line error
+	inResync  bool           // decoder reported errors since the last valid trice
+}
+
+// check returns an "err:" line if the serial line error counters increased since the last check.
+func (p *lineErrorWatch) check(now time.Time) string {
+	p.lastCheck = now
+	le, ok := lineErrors()
+	if !ok {
+		p.valid = false
+		return ""
+	}
+	d := le.Sub(p.last)
+	valid := p.valid
+	p.last, p.valid = le, true
+	if !valid || 0 == d.Total() { // first check is only a reference
+		return ""
+	}
+	p.lastError = now
+	sessionStats.lineErrors(d)
+	return fmt.Sprintln("err:serial line errors:", d, "- electrical problem or wrong line settings?")
+}
+
+// trice returns "err:" lines to emit in front of the decoded string s, which is the decoder output.
+// Decoder errors start with "error:". A sequence of them is one re-sync.
+func (p *lineErrorWatch) trice(s string, now time.Time) (lines string) {
+	isError := strings.HasPrefix(s, "error:")
+	resync := isError && !p.inResync
+	p.inResync = isError
+	if resync || now.Sub(p.lastCheck) > lineErrorInterval {
+		lines = p.check(now)
+	}
+	if !resync {
+		return
+	}
+	afterLineError := p.valid && now.Sub(p.lastError) < lineErrorWindow
+	resyncs, lineErrorResyncs := sessionStats.resync(afterLineError)
+	if afterLineError {
+		lines += fmt.Sprintln("err:decoder re-sync after serial line error,", lineErrorResyncs, "of", resyncs, "re-syncs followed line errors")
+	}
+	return
+}
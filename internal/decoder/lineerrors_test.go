@@ -0,0 +1,55 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rokath/trice/internal/com"
+	"github.com/tj/assert"
+)
+
+func TestLineErrorWatch(t *testing.T) {
+	defer func(f func() (com.LineErrors, bool)) { lineErrors = f }(lineErrors)
+	defer withStats()()
+	le := com.LineErrors{Frame: 7} // old errors from before
+	lineErrors = func() (com.LineErrors, bool) { return le, true }
+	var p lineErrorWatch
+	t0 := time.Now()
+
+	assert.Equal(t, "", p.trice("v=1", t0))                           // reference check
+	assert.Equal(t, "", p.trice("error: unknown triceID", t0))        // re-sync without line error
+	assert.Equal(t, "", p.trice("error: unknown triceID", t0))        // same re-sync
+	assert.Equal(t, "", p.trice("v=2", t0.Add(100*time.Millisecond))) // no check yet
+	assert.Equal(t, 1, sessionStats.resyncs)
+	assert.Equal(t, 0, sessionStats.lineErrorResyncs)
+
+	le.Frame, le.Overrun = 9, 1
+	exp := "err:serial line errors: 2 framing, 1 overrun - electrical problem or wrong line settings?\n" +
+		"err:decoder re-sync after serial line error, 1 of 2 re-syncs followed line errors\n"
+	assert.Equal(t, exp, p.trice("error: unknown triceID", t0.Add(200*time.Millisecond)))
+	assert.Equal(t, 2, sessionStats.resyncs)
+	assert.Equal(t, 1, sessionStats.lineErrorResyncs)
+	assert.Equal(t, com.LineErrors{Frame: 2, Overrun: 1}, sessionStats.lineErrorTotals)
+	assert.True(t, strings.Contains(StatsReport(), "inf:re-syncs: 2 (1 after serial line errors), serial line errors: 2 framing, 1 overrun\n"), StatsReport())
+
+	le.Parity = 1 // found by the periodic check
+	exp = "err:serial line errors: 1 parity - electrical problem or wrong line settings?\n"
+	assert.Equal(t, exp, p.trice("v=3", t0.Add(2*time.Second)))
+	assert.Equal(t, "", p.trice("error: unknown triceID", t0.Add(4*time.Second))) // too late for correlation
+	assert.Equal(t, 3, sessionStats.resyncs)
+	assert.Equal(t, 1, sessionStats.lineErrorResyncs)
+}
+
+func TestLineErrorWatchNoCounters(t *testing.T) {
+	defer func(f func() (com.LineErrors, bool)) { lineErrors = f }(lineErrors)
+	defer withStats()()
+	lineErrors = func() (com.LineErrors, bool) { return com.LineErrors{}, false }
+	var p lineErrorWatch
+	assert.Equal(t, "", p.trice("error: x", time.Now()))
+	assert.Equal(t, 1, sessionStats.resyncs)
+	assert.Equal(t, 0, sessionStats.lineErrorResyncs)
+}
@@ -0,0 +1,39 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/rokath/trice/internal/id"
+)
+
+// NewPack2Decoder provides a decoder instance for the pack2 encoding.
+//
+// The pack2 encoding uses the flex medium and long sub-encoding data layout with a different header:
+// `IIIIIIII IIIIIIII IIIINNNN CCCCCCCC` with a 20-bit ID I, a 4-bit byte count N and an 8-bit cycle counter C.
+// The counts 0...12 are short counts, 0xd means a following long count `LLLLLLLL LLLLLLLL llllllll llllllll`
+// with the 16-bit count L and its bit inverse l. The counts 0xe and 0xf are reserved.
+// Sync packets 0x89abcdef are allowed between trices.
+// l is the trice id list in slice of struct format.
+// in is the usable reader for the input bytes.
+// littleEndian is false on normal network order.
+func NewPack2Decoder(lut id.TriceIDLookUp, m *sync.RWMutex, in io.Reader, endian bool) Decoder {
+	p := NewFlexDecoder(lut, m, in, endian).(*Flex)
+	p.pack2 = true
+	return p
+}
+
+// pack2Encoding interprets the trice with the pack2 header head.
+func (p *Flex) pack2Encoding(head uint32) (n int, err error) {
+	LastTriceID = id.TriceID(head >> 12)   // bits 31...12 are the 20-bit ID
+	count := int((0x00000f00 & head) >> 8) // this nibble is the 4-bit count
+	cycle := int(0x000000ff & head)        // least significant byte is the cycle
+	if 0xd < count {
+		return p.outOfSync("reserved count", fmt.Sprintf("%x", count))
+	}
+	return p.countedTrice(count, 0xd == count, cycle) // values 0-12 are short counts, 0xd is long count
+}
@@ -0,0 +1,186 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"testing"
+)
+
+func TestPack2L(t *testing.T) {
+	doTableTest(t, NewPack2Decoder, littleEndian, pack2TableL)
+}
+
+func TestPack2LStrings(t *testing.T) {
+	doTableTest(t, NewPack2Decoder, littleEndian, pack2StringsL)
+}
+
+func TestPack2(t *testing.T) {
+	glob.Lock()
+	ShowID = "Id(%7d) "
+	defer func() {
+		ShowID = "" // reset to default
+		glob.Unlock()
+	}()
+	doTableTest(t, NewPack2Decoder, bigEndian, pack2TableB)
+}
+
+func TestPack2Errors(t *testing.T) {
+	tt := testTable{ // big endian
+		{[]byte{0x89, 0xab, 0xcd, 0xef, 0xff, 0xc6, 0xf4, 0x01, 0x00, 0x04, 0x00, 0x00}, `MSG: triceFifoMaxDepth = 4, select = 0`},
+		{[]byte{0xff, 0xc6, 0xf4, 0x03, 0x00, 0x04, 0x00, 0x01}, "warning:Cycle 3 does not match expected cyle 2 - lost trice messages?\nMSG: triceFifoMaxDepth = 4, select = 1"},
+		{[]byte{0x0f, 0xeb, 0xdd, 0x04, 0x00, 0x0d, 0xff, 0xf2, 0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28, 0x29, 0x2a, 0x2b, 0x2c, 0x00, 0x00, 0x00}, ` !"#$%&'()*+,`},
+		{[]byte{0xff, 0xc6, 0xfe, 0x05}, "error: reserved count e ignoring first byte [255 198 254 5]"},
+	}
+	doTableTest(t, NewPack2Decoder, bigEndian, tt)
+}
+
+var pack2TableL = testTable{ // little endian
+	{[]byte{0, 112, 34, 10}, `s:                                                   \ns:   MDK-ARM_LL_UART_RTT0_FLEX_STM32F030_NUCLEO-64   \ns:                                                   \n`},
+	{[]byte{1, 244, 198, 255, 0, 0, 4, 0}, `MSG: triceFifoMaxDepth = 4, select = 0`},
+	{[]byte{2, 244, 198, 255, 1, 0, 8, 0}, `MSG: triceFifoMaxDepth = 8, select = 1`},
+	{[]byte{3, 244, 198, 255, 2, 0, 8, 0}, `MSG: triceFifoMaxDepth = 8, select = 2`},
+	{[]byte{4, 104, 133, 13, 0, 0, 0, 0, 109, 0, 0, 0}, `rd:trice64 109`},
+	{[]byte{5, 244, 198, 255, 3, 0, 24, 0}, `MSG: triceFifoMaxDepth = 24, select = 3`},
+	{[]byte{6, 209, 92, 13, 1, 0, 0, 0}, `rd:1`},
+	{[]byte{7, 146, 149, 10, 2, 1, 0, 0}, `rd:1, 2`},
+	{[]byte{8, 99, 25, 10, 3, 2, 1, 0}, `rd:1, 2, 3`},
+	{[]byte{9, 180, 55, 8, 4, 3, 2, 1}, `rd:1, 2, 3, 4`},
+	{[]byte{10, 213, 112, 9, 4, 3, 2, 1, 5, 0, 0, 0}, `rd:1, 2, 3, 4, 5`},
+	{[]byte{11, 38, 123, 13, 4, 3, 2, 1, 6, 5, 0, 0}, `rd:1, 2, 3, 4, 5, 6`},
+	{[]byte{12, 135, 63, 9, 4, 3, 2, 1, 7, 6, 5, 0}, `rd:1, 2, 3, 4, 5, 6, 7`},
+	{[]byte{13, 232, 1, 11, 4, 3, 2, 1, 8, 7, 6, 5}, `rd:1, 2, 3, 4, 5, 6, 7, 8`},
+	{[]byte{14, 50, 46, 11, 1, 0, 0, 0}, `rd:1`},
+	{[]byte{15, 180, 2, 13, 2, 0, 1, 0}, `rd:1, 2`},
+	{[]byte{16, 166, 162, 15, 2, 0, 1, 0, 3, 0, 0, 0}, `rd:1, 2, 3`},
+	{[]byte{17, 40, 57, 8, 2, 0, 1, 0, 4, 0, 3, 0}, `rd:1, 2, 3, 4`},
+	{[]byte{18, 196, 136, 8, 1, 0, 0, 0}, `rd:1`},
+	{[]byte{19, 8, 43, 11, 1, 0, 0, 0, 2, 0, 0, 0}, `rd:1, 2`},
+	{[]byte{20, 156, 68, 15, 1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0}, `rd:1, 2, 3`},
+	{[]byte{21, 141, 57, 15, 239, 255, 16, 0, 1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0, 4, 0, 0, 0}, `rd:1, 2, 3, 4`},
+	{[]byte{22, 104, 58, 14, 0, 0, 0, 0, 1, 0, 0, 0}, `rd:1`},
+	{[]byte{23, 61, 90, 14, 239, 255, 16, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0}, `rd:1, 2`},
+	{[]byte{24, 145, 147, 13, 1, 0, 0, 0}, `rd:1`},
+	{[]byte{25, 2, 132, 12, 2, 1, 0, 0}, `rd:1, 2`},
+	{[]byte{26, 131, 198, 9, 3, 2, 1, 0}, `rd:1, 2, 3`},
+	{[]byte{27, 228, 129, 15, 4, 3, 2, 1}, `rd:1, 2, 3, 4`},
+	{[]byte{28, 149, 73, 10, 4, 3, 2, 1, 5, 0, 0, 0}, `rd:1, 2, 3, 4, 5`},
+	{[]byte{29, 198, 210, 9, 4, 3, 2, 1, 6, 5, 0, 0}, `rd:1, 2, 3, 4, 5, 6`},
+	{[]byte{30, 23, 143, 13, 4, 3, 2, 1, 7, 6, 5, 0}, `rd:1, 2, 3, 4, 5, 6, 7`},
+	{[]byte{31, 152, 160, 14, 4, 3, 2, 1, 8, 7, 6, 5}, `rd:1, 2, 3, 4, 5, 6, 7, 8`},
+	{[]byte{32, 82, 76, 8, 1, 0, 0, 0}, `rd:1`},
+	{[]byte{33, 244, 191, 9, 2, 0, 1, 0}, `rd:1, 2`},
+	{[]byte{34, 198, 196, 8, 2, 0, 1, 0, 3, 0, 0, 0}, `rd:1, 2, 3`},
+	{[]byte{35, 152, 30, 9, 2, 0, 1, 0, 4, 0, 3, 0}, `rd:1, 2, 3, 4`},
+	{[]byte{36, 84, 95, 12, 1, 0, 0, 0}, `rd:1`},
+	{[]byte{37, 56, 190, 9, 1, 0, 0, 0, 2, 0, 0, 0}, `rd:1, 2`},
+	{[]byte{38, 76, 75, 15, 1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0}, `rd:1, 2, 3`},
+	{[]byte{39, 221, 40, 10, 239, 255, 16, 0, 1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0, 4, 0, 0, 0}, `rd:1, 2, 3, 4`},
+	{[]byte{40, 120, 116, 9, 0, 0, 0, 0, 1, 0, 0, 0}, `rd:1`},
+	{[]byte{41, 109, 137, 9, 239, 255, 16, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0}, `rd:1, 2`},
+	{[]byte{42, 244, 198, 255, 4, 0, 0, 2}, `MSG: triceFifoMaxDepth = 512, select = 4`},
+	{[]byte{43, 65, 171, 11, 1, 0, 0, 0}, `rd:1`},
+	{[]byte{44, 50, 229, 15, 2, 1, 0, 0}, `rd:1, 2`},
+	{[]byte{45, 83, 80, 12, 3, 2, 1, 0}, `rd:1, 2, 3`},
+	{[]byte{46, 4, 81, 13, 4, 3, 2, 1}, `rd:1, 2, 3, 4`},
+	{[]byte{47, 197, 132, 13, 4, 3, 2, 1, 5, 0, 0, 0}, `rd:1, 2, 3, 4, 5`},
+	{[]byte{48, 230, 55, 15, 4, 3, 2, 1, 6, 5, 0, 0}, `rd:1, 2, 3, 4, 5, 6`},
+	{[]byte{49, 119, 24, 13, 4, 3, 2, 1, 7, 6, 5, 0}, `rd:1, 2, 3, 4, 5, 6, 7`},
+	{[]byte{50, 104, 163, 15, 4, 3, 2, 1, 8, 7, 6, 5}, `rd:1, 2, 3, 4, 5, 6, 7, 8`},
+	{[]byte{51, 146, 65, 10, 1, 0, 0, 0}, `rd:1`},
+	{[]byte{52, 164, 244, 13, 2, 0, 1, 0}, `rd:1, 2`},
+	{[]byte{53, 118, 121, 10, 2, 0, 1, 0, 3, 0, 0, 0}, `rd:1, 2, 3`},
+	{[]byte{54, 136, 164, 14, 2, 0, 1, 0, 4, 0, 3, 0}, `rd:1, 2, 3, 4`},
+	{[]byte{55, 148, 206, 15, 1, 0, 0, 0}, `rd:1`},
+	{[]byte{56, 88, 205, 13, 1, 0, 0, 0, 2, 0, 0, 0}, `rd:1, 2`},
+	{[]byte{57, 204, 216, 15, 1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0}, `rd:1, 2, 3`},
+	{[]byte{58, 173, 62, 13, 239, 255, 16, 0, 1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0, 4, 0, 0, 0}, `rd:1, 2, 3, 4`},
+	{[]byte{59, 24, 150, 14, 0, 0, 0, 0, 1, 0, 0, 0}, `rd:1`},
+}
+
+var pack2StringsL = testTable{ // little endian, short and long counts
+	{[]byte{83, 208, 235, 15}, ``},
+	{[]byte{84, 209, 235, 15, 32, 0, 0, 0}, ` `},
+	{[]byte{85, 210, 235, 15, 32, 33, 0, 0}, ` !`},
+	{[]byte{86, 211, 235, 15, 32, 33, 34, 0}, ` !"`},
+	{[]byte{87, 212, 235, 15, 32, 33, 34, 35}, ` !"#`},
+	{[]byte{88, 213, 235, 15, 32, 33, 34, 35, 36, 0, 0, 0}, ` !"#$`},
+	{[]byte{89, 214, 235, 15, 32, 33, 34, 35, 36, 37, 0, 0}, ` !"#$%`},
+	{[]byte{90, 215, 235, 15, 32, 33, 34, 35, 36, 37, 38, 0}, ` !"#$%&`},
+	{[]byte{91, 216, 235, 15, 32, 33, 34, 35, 36, 37, 38, 39}, ` !"#$%&'`},
+	{[]byte{92, 217, 235, 15, 32, 33, 34, 35, 36, 37, 38, 39, 40, 0, 0, 0}, ` !"#$%&'(`},
+	{[]byte{93, 218, 235, 15, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 0, 0}, ` !"#$%&'()`},
+	{[]byte{94, 219, 235, 15, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 0}, ` !"#$%&'()*`},
+	{[]byte{95, 220, 235, 15, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43}, ` !"#$%&'()*+`},
+	{[]byte{96, 221, 235, 15, 242, 255, 13, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 0, 0, 0}, ` !"#$%&'()*+,`},
+	{[]byte{97, 221, 235, 15, 241, 255, 14, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 0, 0}, ` !"#$%&'()*+,-`},
+	{[]byte{98, 221, 235, 15, 240, 255, 15, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 0}, ` !"#$%&'()*+,-.`},
+	{[]byte{99, 221, 235, 15, 239, 255, 16, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47}, ` !"#$%&'()*+,-./`},
+	{[]byte{100, 221, 235, 15, 238, 255, 17, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 0, 0, 0}, ` !"#$%&'()*+,-./0`},
+	{[]byte{101, 221, 235, 15, 237, 255, 18, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 0, 0}, ` !"#$%&'()*+,-./01`},
+	{[]byte{102, 221, 235, 15, 236, 255, 19, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 0}, ` !"#$%&'()*+,-./012`},
+	{[]byte{103, 244, 198, 255, 15, 0, 120, 3}, `MSG: triceFifoMaxDepth = 888, select = 15`},
+	{[]byte{104, 221, 235, 15, 225, 255, 30, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 0, 0}, ` !"#$%&'()*+,-./0123456789:;<=`},
+	{[]byte{105, 221, 235, 15, 224, 255, 31, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 0}, ` !"#$%&'()*+,-./0123456789:;<=>`},
+	{[]byte{106, 221, 235, 15, 223, 255, 32, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63}, ` !"#$%&'()*+,-./0123456789:;<=>?`},
+	{[]byte{107, 221, 235, 15, 222, 255, 33, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 0, 0, 0}, ` !"#$%&'()*+,-./0123456789:;<=>?@`},
+	{[]byte{108, 221, 235, 15, 221, 255, 34, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 0, 0}, ` !"#$%&'()*+,-./0123456789:;<=>?@A`},
+	{[]byte{109, 244, 198, 255, 16, 0, 120, 3}, `MSG: triceFifoMaxDepth = 888, select = 16`},
+	{[]byte{110, 221, 235, 15, 129, 255, 126, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 32, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 32, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 0, 0}, ` !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_ abcdefghijklmnopqrstuvwxyz{|}~  !"#$%&'()*+,-./0123456789:;<=`},
+	{[]byte{111, 221, 235, 15, 128, 255, 127, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 32, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 32, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 0}, ` !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_ abcdefghijklmnopqrstuvwxyz{|}~  !"#$%&'()*+,-./0123456789:;<=>`},
+	{[]byte{112, 221, 235, 15, 127, 255, 128, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 32, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 32, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63}, ` !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_ abcdefghijklmnopqrstuvwxyz{|}~  !"#$%&'()*+,-./0123456789:;<=>?`},
+	{[]byte{113, 221, 235, 15, 126, 255, 129, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 32, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 32, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 32, 0, 0, 0}, ` !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_ abcdefghijklmnopqrstuvwxyz{|}~  !"#$%&'()*+,-./0123456789:;<=>? `},
+	{[]byte{114, 244, 198, 255, 17, 0, 120, 3}, `MSG: triceFifoMaxDepth = 888, select = 17`},
+	{[]byte{115, 221, 235, 15, 1, 255, 254, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 32, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 32, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 32, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 32, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 0, 0}, ` !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_ abcdefghijklmnopqrstuvwxyz{|}~  !"#$%&'()*+,-./0123456789:;<=>? !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_ abcdefghijklmnopqrstuvwxyz{|}~  !"#$%&'()*+,-./0123456789:;<=`},
+	{[]byte{116, 221, 235, 15, 0, 255, 255, 0, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 32, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 32, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 32, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 32, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 0}, ` !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_ abcdefghijklmnopqrstuvwxyz{|}~  !"#$%&'()*+,-./0123456789:;<=>? !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_ abcdefghijklmnopqrstuvwxyz{|}~  !"#$%&'()*+,-./0123456789:;<=>`},
+	{[]byte{117, 244, 198, 255, 18, 0, 120, 3}, `MSG: triceFifoMaxDepth = 888, select = 18`},
+	{[]byte{118, 221, 235, 15, 254, 254, 1, 1, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 32, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 32, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 32, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 32, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 32, 0, 0, 0}, ` !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_ abcdefghijklmnopqrstuvwxyz{|}~  !"#$%&'()*+,-./0123456789:;<=>? !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_ abcdefghijklmnopqrstuvwxyz{|}~  !"#$%&'()*+,-./0123456789:;<=>? `},
+	{[]byte{119, 221, 235, 15, 253, 254, 2, 1, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 32, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 32, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 32, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 32, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 32, 33, 0, 0}, ` !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_ abcdefghijklmnopqrstuvwxyz{|}~  !"#$%&'()*+,-./0123456789:;<=>? !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_ abcdefghijklmnopqrstuvwxyz{|}~  !"#$%&'()*+,-./0123456789:;<=>? !`},
+	{[]byte{120, 244, 198, 255, 19, 0, 120, 3}, `MSG: triceFifoMaxDepth = 888, select = 19`},
+	{[]byte{121, 244, 198, 255, 20, 0, 120, 3}, `MSG: triceFifoMaxDepth = 888, select = 20`},
+	{[]byte{122, 56, 36, 0, 129, 129, 129, 129, 129, 129, 129, 129}, `tst:TRICE8_1 %d=-127, %u=129, 0x%x=0x-7f, 0x%2x=0x-7f, 0x%02x=0x-7f, 0x%3x=0x-7f, 0x%03x=0x-7f, %b=-1111111`},
+	{[]byte{123, 34, 57, 5, 160, 0, 0, 0}, `tst:TRICE16_1 0x00a0`},
+}
+
+var pack2TableB = testTable{ // big endian
+	{[]byte{255, 198, 244, 182, 3, 120, 0, 31}, `Id(1047663) MSG: triceFifoMaxDepth = 888, select = 31`},
+	{[]byte{255, 198, 244, 183, 3, 120, 0, 0}, `Id(1047663) MSG: triceFifoMaxDepth = 888, select = 0`},
+	{[]byte{255, 198, 244, 184, 3, 120, 0, 1}, `Id(1047663) MSG: triceFifoMaxDepth = 888, select = 1`},
+	{[]byte{255, 198, 244, 185, 3, 120, 0, 2}, `Id(1047663) MSG: triceFifoMaxDepth = 888, select = 2`},
+	{[]byte{13, 133, 104, 186, 0, 0, 0, 0, 0, 0, 0, 109}, `Id(  55382) rd:trice64 109`},
+	{[]byte{255, 198, 244, 187, 3, 120, 0, 3}, `Id(1047663) MSG: triceFifoMaxDepth = 888, select = 3`},
+	{[]byte{13, 92, 209, 188, 0, 0, 0, 1}, `Id(  54733) rd:1`},
+	{[]byte{10, 149, 146, 189, 0, 0, 1, 2}, `Id(  43353) rd:1, 2`},
+	{[]byte{10, 25, 99, 190, 0, 1, 2, 3}, `Id(  41366) rd:1, 2, 3`},
+	{[]byte{8, 55, 180, 191, 1, 2, 3, 4}, `Id(  33659) rd:1, 2, 3, 4`},
+	{[]byte{9, 112, 213, 192, 1, 2, 3, 4, 0, 0, 0, 5}, `Id(  38669) rd:1, 2, 3, 4, 5`},
+	{[]byte{13, 123, 38, 193, 1, 2, 3, 4, 0, 0, 5, 6}, `Id(  55218) rd:1, 2, 3, 4, 5, 6`},
+	{[]byte{9, 63, 135, 194, 1, 2, 3, 4, 0, 5, 6, 7}, `Id(  37880) rd:1, 2, 3, 4, 5, 6, 7`},
+	{[]byte{11, 1, 232, 195, 1, 2, 3, 4, 5, 6, 7, 8}, `Id(  45086) rd:1, 2, 3, 4, 5, 6, 7, 8`},
+	{[]byte{11, 46, 50, 196, 0, 0, 0, 1}, `Id(  45795) rd:1`},
+	{[]byte{13, 2, 180, 197, 0, 1, 0, 2}, `Id(  53291) rd:1, 2`},
+	{[]byte{15, 162, 166, 198, 0, 1, 0, 2, 0, 0, 0, 3}, `Id(  64042) rd:1, 2, 3`},
+	{[]byte{8, 57, 40, 199, 0, 1, 0, 2, 0, 3, 0, 4}, `Id(  33682) rd:1, 2, 3, 4`},
+	{[]byte{8, 136, 196, 200, 0, 0, 0, 1}, `Id(  34956) rd:1`},
+	{[]byte{11, 43, 8, 201, 0, 0, 0, 1, 0, 0, 0, 2}, `Id(  45744) rd:1, 2`},
+	{[]byte{15, 68, 156, 202, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 3}, `Id(  62537) rd:1, 2, 3`},
+	{[]byte{15, 57, 141, 203, 0, 16, 255, 239, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0, 4}, `Id(  62360) rd:1, 2, 3, 4`},
+	{[]byte{14, 58, 104, 204, 0, 0, 0, 0, 0, 0, 0, 1}, `Id(  58278) rd:1`},
+	{[]byte{14, 90, 61, 205, 0, 16, 255, 239, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2}, `Id(  58787) rd:1, 2`},
+	{[]byte{13, 147, 145, 206, 0, 0, 0, 1}, `Id(  55609) rd:1`},
+	{[]byte{12, 132, 2, 207, 0, 0, 1, 2}, `Id(  51264) rd:1, 2`},
+	{[]byte{9, 198, 131, 208, 0, 1, 2, 3}, `Id(  40040) rd:1, 2, 3`},
+	{[]byte{15, 129, 228, 209, 1, 2, 3, 4}, `Id(  63518) rd:1, 2, 3, 4`},
+	{[]byte{10, 73, 149, 210, 1, 2, 3, 4, 0, 0, 0, 5}, `Id(  42137) rd:1, 2, 3, 4, 5`},
+	{[]byte{9, 210, 198, 211, 1, 2, 3, 4, 0, 0, 5, 6}, `Id(  40236) rd:1, 2, 3, 4, 5, 6`},
+	{[]byte{13, 143, 23, 212, 1, 2, 3, 4, 0, 5, 6, 7}, `Id(  55537) rd:1, 2, 3, 4, 5, 6, 7`},
+	{[]byte{14, 160, 152, 213, 1, 2, 3, 4, 5, 6, 7, 8}, `Id(  59913) rd:1, 2, 3, 4, 5, 6, 7, 8`},
+	{[]byte{8, 76, 82, 214, 0, 0, 0, 1}, `Id(  33989) rd:1`},
+	{[]byte{9, 191, 244, 215, 0, 1, 0, 2}, `Id(  39935) rd:1, 2`},
+	{[]byte{8, 196, 198, 216, 0, 1, 0, 2, 0, 0, 0, 3}, `Id(  35916) rd:1, 2, 3`},
+	{[]byte{9, 30, 152, 217, 0, 1, 0, 2, 0, 3, 0, 4}, `Id(  37353) rd:1, 2, 3, 4`},
+	{[]byte{12, 95, 84, 218, 0, 0, 0, 1}, `Id(  50677) rd:1`},
+	{[]byte{9, 190, 56, 219, 0, 0, 0, 1, 0, 0, 0, 2}, `Id(  39907) rd:1, 2`},
+	{[]byte{15, 75, 76, 220, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 3}, `Id(  62644) rd:1, 2, 3`},
+	{[]byte{10, 40, 221, 221, 0, 16, 255, 239, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0, 4}, `Id(  41613) rd:1, 2, 3, 4`},
+}
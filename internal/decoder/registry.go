@@ -0,0 +1,174 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/rokath/trice/internal/id"
+	"github.com/rokath/trice/pkg/msg"
+)
+
+// NewDecoder is the constructor type for a decoder. in is the byte stream to decode and
+// endian is BigEndian or LittleEndian according to the selected encoding name.
+type NewDecoder func(lut id.TriceIDLookUp, m *sync.RWMutex, in io.Reader, endian bool) Decoder
+
+// Registration describes an encoding for Register.
+type Registration struct {
+	Name         string     // Name is the encoding name used with -encoding. Names are case insensitive.
+	Aliases      []string   // Aliases are additional names for the encoding.
+	Description  string     // Description is a one line text for the trice help.
+	New          NewDecoder // New creates a decoder for the encoding.
+	BigEndian    bool       // BigEndian is true if the encoding supports big endian (network order), selected by Name.
+	LittleEndian bool       // LittleEndian is true if the encoding supports little endian, selected by Name with "L" appended like "flexL".
+}
+
+const (
+	// BigEndian is the endian parameter value for NewDecoder for big endian (network order) byte streams.
+	BigEndian = bigEndian
+
+	// LittleEndian is the endian parameter value for NewDecoder for little endian byte streams.
+	LittleEndian = littleEndian
+)
+
+// registry contains the registered encodings in registration order.
+var registry []Registration
+
+// framings maps the framing names, usable as encoding name suffix like in "flexL+cobs", to a constructor wrapping the inner reader.
+// The wrapping reader delivers one frame with each Read, see receiver.Framer.
+var framings = map[string]func(in io.Reader) io.Reader{
+	"cobs": newCOBSReader,
+}
+
+func init() {
+	msg.FatalOnErr(Register(Registration{
+		Name:        "esc",
+		Description: "Escape sequence based encoding with 0xEC as start byte, big endian only.",
+		New:         NewEscDecoder,
+		BigEndian:   true,
+	}))
+	msg.FatalOnErr(Register(Registration{
+		Name:         "flex",
+		Description:  "Flexible encoding with 15-bit and 20-bit IDs and sync packets, use 'flexL' for little endian targets.",
+		New:          NewFlexDecoder,
+		BigEndian:    true,
+		LittleEndian: true,
+	}))
+	msg.FatalOnErr(Register(Registration{
+		Name:         "flexCRC",
+		Description:  "Flex encoding with a CRC-8 trailer after short and a CRC-16 trailer after medium and long trices, use 'flexCRCL' for little endian targets.",
+		New:          NewFlexCRCDecoder,
+		BigEndian:    true,
+		LittleEndian: true,
+	}))
+	msg.FatalOnErr(Register(Registration{
+		Name:         "pack2",
+		Description:  "Encoding with 20-bit IDs, cycle counter and runtime strings up to 65535 chars, use 'pack2L' for little endian targets.",
+		New:          NewPack2Decoder,
+		BigEndian:    true,
+		LittleEndian: true,
+	}))
+}
+
+// names returns the encoding names selecting r with their endianness.
+func (r Registration) names() map[string]bool {
+	n := make(map[string]bool)
+	for _, s := range append([]string{r.Name}, r.Aliases...) {
+		s = strings.ToLower(s)
+		if r.LittleEndian {
+			n[s+"l"] = littleEndian
+		}
+		if r.BigEndian {
+			n[s] = bigEndian
+		} else if r.LittleEndian {
+			n[s] = littleEndian
+		}
+	}
+	return n
+}
+
+// Register adds an encoding. It returns an error if a name is used already or if the registration is incomplete.
+// Register is usually called in an init function of the package implementing the encoding.
+// Packages outside this module use github.com/rokath/trice/pkg/decoder.Register and build their decoder on Base.
+func Register(r Registration) error {
+	if "" == r.Name || nil == r.New || !(r.BigEndian || r.LittleEndian) {
+		return fmt.Errorf("incomplete encoding registration %q, need a name, a constructor and a supported endianness", r.Name)
+	}
+	for _, s := range append([]string{r.Name}, r.Aliases...) {
+		if strings.Contains(s, "+") {
+			return fmt.Errorf("encoding name %s contains '+', which separates the framing", s)
+		}
+	}
+	for _, x := range registry {
+		have := x.names()
+		for s := range r.names() {
+			if _, ok := have[s]; ok {
+				return fmt.Errorf("encoding name %s is used by encoding %s already", s, x.Name)
+			}
+		}
+	}
+	registry = append(registry, r)
+	return nil
+}
+
+// Lookup returns the registration and the endianness for the encoding name.
+// The name can have a framing suffix like "flexL+cobs", which is checked but not part of the result.
+func Lookup(name string) (r Registration, endian bool, err error) {
+	name, _, err = splitFraming(name)
+	if nil != err {
+		return
+	}
+	for _, x := range registry {
+		if e, ok := x.names()[strings.ToLower(name)]; ok {
+			return x, e, nil
+		}
+	}
+	return r, endian, fmt.Errorf("unknown encoding %s, known are %s", name, strings.Join(EncodingNames(), "|"))
+}
+
+// splitFraming separates the framing suffix from the encoding name and returns the framing reader constructor, which is nil without suffix.
+func splitFraming(name string) (encoding string, framing func(in io.Reader) io.Reader, err error) {
+	i := strings.Index(name, "+")
+	if i < 0 {
+		return name, nil, nil
+	}
+	framing, ok := framings[strings.ToLower(name[i+1:])]
+	if !ok {
+		return name, nil, fmt.Errorf("unknown framing %s in encoding %s, known is cobs", name[i+1:], name)
+	}
+	return name[:i], framing, nil
+}
+
+// EncodingNames returns the names usable with -encoding without aliases, like "flex" and "flexL".
+func EncodingNames() (s []string) {
+	for _, r := range registry {
+		if r.BigEndian {
+			s = append(s, r.Name)
+		}
+		if r.LittleEndian && r.BigEndian {
+			s = append(s, r.Name+"L")
+		} else if r.LittleEndian {
+			s = append(s, r.Name)
+		}
+	}
+	return
+}
+
+// EncodingInfo returns a help text with a line for each registered encoding.
+func EncodingInfo() string {
+	var lines []string
+	for _, r := range registry {
+		names := []string{r.Name}
+		if r.LittleEndian && r.BigEndian {
+			names = append(names, r.Name+"L")
+		}
+		names = append(names, r.Aliases...)
+		lines = append(lines, fmt.Sprintf("%s: %s", strings.Join(names, "|"), r.Description))
+	}
+	lines = append(lines, "+cobs: Append to an encoding name like 'flexL+cobs' for COBS framed trices with 0 as frame delimiter. A corrupted frame is discarded as a whole.")
+	return strings.Join(lines, "\n")
+}
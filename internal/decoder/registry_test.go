@@ -0,0 +1,68 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func TestLookup(t *testing.T) {
+	for _, x := range []struct {
+		name   string
+		exp    string
+		endian bool
+	}{
+		{"esc", "esc", BigEndian},
+		{"ESC", "esc", BigEndian},
+		{"flex", "flex", BigEndian},
+		{"FLEX", "flex", BigEndian},
+		{"flexL", "flex", LittleEndian},
+		{"flexl", "flex", LittleEndian},
+		{"FLEXL", "flex", LittleEndian},
+		{"flexL+cobs", "flex", LittleEndian},
+		{"esc+COBS", "esc", BigEndian},
+	} {
+		r, endian, err := Lookup(x.name)
+		assert.Nil(t, err, x.name)
+		assert.Equal(t, x.exp, r.Name, x.name)
+		assert.Equal(t, x.endian, endian, x.name)
+	}
+	_, _, err := Lookup("escL")
+	assert.NotNil(t, err)
+	_, _, err = Lookup("unknown")
+	assert.NotNil(t, err)
+	_, _, err = Lookup("flexL+unknown")
+	assert.NotNil(t, err)
+}
+
+func TestRegister(t *testing.T) {
+	defer func(r []Registration) { registry = r }(append([]Registration{}, registry...))
+	assert.NotNil(t, Register(Registration{Name: "cobs", BigEndian: true}))                      // no constructor
+	assert.NotNil(t, Register(Registration{Name: "cobs", New: NewFlexDecoder}))                  // no endianness
+	assert.NotNil(t, Register(Registration{Name: "FLEX", New: NewFlexDecoder, BigEndian: true})) // name in use
+	assert.NotNil(t, Register(Registration{Name: "fle", Aliases: []string{"esc"}, New: NewEscDecoder, LittleEndian: true}))
+	assert.NotNil(t, Register(Registration{Name: "my+cobs", New: NewFlexDecoder, LittleEndian: true})) // '+' separates the framing
+
+	assert.Nil(t, Register(Registration{Name: "my", Aliases: []string{"company"}, Description: "Company encoding.", New: NewFlexDecoder, LittleEndian: true}))
+	for _, name := range []string{"my", "myL", "company", "COMPANYL"} {
+		r, endian, err := Lookup(name)
+		assert.Nil(t, err, name)
+		assert.Equal(t, "my", r.Name)
+		assert.Equal(t, LittleEndian, endian)
+	}
+	assert.Equal(t, []string{"esc", "flex", "flexL", "flexCRC", "flexCRCL", "pack2", "pack2L", "my"}, EncodingNames())
+	assert.Contains(t, EncodingInfo(), "my|company: Company encoding.")
+
+	defer func(s string) { Encoding = s }(Encoding)
+	Encoding = "company"
+	dec, err := newEncodingDecoder(nil, nil, nil)
+	assert.Nil(t, err)
+	_, ok := dec.(*Flex)
+	assert.True(t, ok)
+	Encoding = "none"
+	_, err = newEncodingDecoder(nil, nil, nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,224 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rokath/trice/internal/com"
+	"github.com/rokath/trice/internal/emitter"
+	"github.com/rokath/trice/internal/id"
+)
+
+var (
+	// Stats enables the decoder statistics, which are reported at exit, see StatsReport.
+	Stats bool
+
+	// StatsInterval is the time between two statistics reports during logging, if Stats is true. 0 means only at exit.
+	StatsInterval time.Duration
+
+	// statsTop is the max count of listed IDs in a statistics report.
+	statsTop = 10
+
+	// sessionStats are the statistics of all decoders since program start.
+	sessionStats = newStatistics()
+)
+
+// statistics are the decoder health counters.
+type statistics struct {
+	mutex     sync.Mutex
+	trices    map[id.TriceID]int // decoded trices per ID
+	channels  map[string]int     // decoded trices per channel, "" for trices without channel
+	unknown   map[id.TriceID]int // unknown IDs
+	outOfSync map[string]int     // decoder errors per reason
+	discarded int                // bytes removed without decoding
+	lost      int                // estimated lost trices from cycle counter gaps
+	syncs     int                // sync packets
+
+	resyncs          int            // decoder re-syncs, counted also without Stats
+	lineErrorResyncs int            // re-syncs shortly after serial line errors
+	lineErrorTotals  com.LineErrors // serial line errors reported by the serial driver
+}
+
+func newStatistics() *statistics {
+	p := &statistics{}
+	p.reset()
+	return p
+}
+
+// reset clears all counters.
+func (p *statistics) reset() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.trices = make(map[id.TriceID]int)
+	p.channels = make(map[string]int)
+	p.unknown = make(map[id.TriceID]int)
+	p.outOfSync = make(map[string]int)
+	p.discarded, p.lost, p.syncs = 0, 0, 0
+	p.resyncs, p.lineErrorResyncs, p.lineErrorTotals = 0, 0, com.LineErrors{}
+}
+
+// decoderStats returns the statistics a new decoder uses or nil if Stats is false.
+func decoderStats() *statistics {
+	if Stats {
+		return sessionStats
+	}
+	return nil
+}
+
+// All statistics counter methods do nothing on a nil receiver, so decoders without statistics need no checks.
+
+// trice counts a decoded trice with ID triceID and format string strg.
+func (p *statistics) trice(triceID id.TriceID, strg string) {
+	if nil == p {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.trices[triceID]++
+	p.channels[emitter.Channel(strg)]++
+}
+
+// unknownID counts a not in the ID list found trice ID.
+func (p *statistics) unknownID(triceID id.TriceID) {
+	if nil == p {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.unknown[triceID]++
+}
+
+// sync counts a sync packet.
+func (p *statistics) sync() {
+	if nil == p {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.syncs++
+}
+
+// lostTrices adds n estimated lost trices.
+func (p *statistics) lostTrices(n int) {
+	if nil == p {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.lost += n
+}
+
+// discard counts n removed bytes. If reason is not "", it counts also a decoder error.
+func (p *statistics) discard(reason string, n int) {
+	if nil == p {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.discarded += n
+	if "" != reason {
+		p.outOfSync[reason]++
+	}
+}
+
+// resync counts a decoder re-sync and returns the re-sync counts. afterLineError is true for a re-sync shortly after serial line errors.
+func (p *statistics) resync(afterLineError bool) (resyncs, lineErrorResyncs int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.resyncs++
+	if afterLineError {
+		p.lineErrorResyncs++
+	}
+	return p.resyncs, p.lineErrorResyncs
+}
+
+// lineErrors adds the serial line errors d to the totals.
+func (p *statistics) lineErrors(d com.LineErrors) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.lineErrorTotals = p.lineErrorTotals.Add(d)
+}
+
+// report returns the statistics as "inf:" lines.
+func (p *statistics) report() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	var s strings.Builder
+	var total int
+	for _, n := range p.trices {
+		total += n
+	}
+	fmt.Fprintf(&s, "inf:decoder statistics: %d trices with %d IDs, %d sync packets, %d discarded bytes, %d lost trices (estimated from cycle counter)\n",
+		total, len(p.trices), p.syncs, p.discarded, p.lost)
+	if 0 < len(p.trices) {
+		fmt.Fprintln(&s, "inf:trices per ID:", topIDs(p.trices))
+	}
+	if 0 < len(p.channels) {
+		fmt.Fprintln(&s, "inf:trices per channel:", channelCounts(p.channels))
+	}
+	if 0 < len(p.unknown) {
+		fmt.Fprintln(&s, "inf:unknown IDs:", topIDs(p.unknown))
+	}
+	reasons := make([]string, 0, len(p.outOfSync))
+	for r := range p.outOfSync {
+		reasons = append(reasons, r)
+	}
+	sort.Strings(reasons)
+	for _, r := range reasons {
+		fmt.Fprintf(&s, "inf:decoder error %dx: %s\n", p.outOfSync[r], r)
+	}
+	fmt.Fprintf(&s, "inf:re-syncs: %d (%d after serial line errors), serial line errors: %v\n", p.resyncs, p.lineErrorResyncs, p.lineErrorTotals)
+	return s.String()
+}
+
+// StatsReport returns the decoder statistics since program start as "inf:" lines.
+func StatsReport() string {
+	return sessionStats.report()
+}
+
+// topIDs returns the statsTop most frequent IDs in m with their counts.
+func topIDs(m map[id.TriceID]int) string {
+	ids := make([]id.TriceID, 0, len(m))
+	for i := range m {
+		ids = append(ids, i)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if m[ids[i]] != m[ids[j]] {
+			return m[ids[i]] > m[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	var l []string
+	for i, x := range ids {
+		if statsTop == i {
+			l = append(l, fmt.Sprint("... ", len(ids)-statsTop, " more"))
+			break
+		}
+		l = append(l, fmt.Sprint(x, ":", m[x]))
+	}
+	return strings.Join(l, ", ")
+}
+
+// channelCounts returns the channels in m in alphabetical order with their counts.
+func channelCounts(m map[string]int) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	l := make([]string, 0, len(keys))
+	for _, k := range keys {
+		c := k
+		if "" == c {
+			c = "none"
+		}
+		l = append(l, fmt.Sprint(c, ":", m[k]))
+	}
+	return strings.Join(l, ", ")
+}
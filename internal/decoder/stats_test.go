@@ -0,0 +1,66 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rokath/trice/internal/id"
+	"github.com/tj/assert"
+)
+
+func TestStats(t *testing.T) {
+	defer withStats()()
+	lu := tilLookUp(t)
+	in := []byte{1, 124, 227, 255, 0, 0, 4, 0}         // TRICE16_2
+	in = append(in, 239, 205, 171, 137)                // sync packet
+	in = append(in, 232, 253, 208, 52)                 // Trice16_1
+	in = append(in, 1, 2, 3, 4)                        // unknown ID, the re-sync discards these 4 bytes
+	in = append(in, 4, 124, 227, 255, 1, 0, 8, 0)      // TRICE16_2 with 2 lost trices
+	in = append(in, 0, 0, 11, 105, 239, 205, 171, 137) // Trice0, sync packet
+	dec := NewFlexDecoder(lu, new(sync.RWMutex), bytes.NewReader(in), littleEndian)
+	e := readEvents(t, dec)
+	assert.Equal(t, 10, len(e)) // 4 errors during re-sync
+
+	p := sessionStats
+	assert.Equal(t, map[id.TriceID]int{1047663: 2, 13520: 1, 26891: 1}, p.trices)
+	assert.Equal(t, map[string]int{"msg": 2, "rd": 1, "wr": 1}, p.channels)
+	assert.Equal(t, map[id.TriceID]int{1027: 1, 1028: 1, 31748: 1, 814976: 1}, p.unknown)
+	assert.Equal(t, map[string]int{"unknown triceID": 4}, p.outOfSync)
+	assert.Equal(t, 4, p.discarded)
+	assert.Equal(t, 2, p.lost)
+	assert.Equal(t, 2, p.syncs)
+
+	r := StatsReport()
+	assert.True(t, strings.HasPrefix(r, "inf:decoder statistics: 4 trices with 3 IDs, 2 sync packets, 4 discarded bytes, 2 lost trices"), r)
+	assert.True(t, strings.Contains(r, "inf:trices per ID: 1047663:2, 13520:1, 26891:1\n"), r)
+	assert.True(t, strings.Contains(r, "inf:trices per channel: msg:2, rd:1, wr:1\n"), r)
+	assert.True(t, strings.Contains(r, "inf:unknown IDs: 1027:1, 1028:1, 31748:1, 814976:1\n"), r)
+	assert.True(t, strings.Contains(r, "inf:decoder error 4x: unknown triceID\n"), r)
+}
+
+func TestStatsOff(t *testing.T) {
+	defer func(s bool) { Stats = s }(Stats)
+	defer sessionStats.reset()
+	Stats = false
+	sessionStats.reset()
+	lu := tilLookUp(t)
+	in := []byte{1, 124, 227, 255, 0, 0, 4, 0, 1, 2, 3, 4}
+	readEvents(t, NewFlexDecoder(lu, new(sync.RWMutex), bytes.NewReader(in), littleEndian))
+	Stats = true
+	valid, _ := Plausibility(lu, new(sync.RWMutex), in)
+	assert.Equal(t, 1, valid)
+	assert.Equal(t, 0, len(sessionStats.trices))
+	assert.Equal(t, 0, len(sessionStats.unknown))
+	assert.Equal(t, 0, sessionStats.discarded)
+}
+
+func TestTopIDs(t *testing.T) {
+	defer func(n int) { statsTop = n }(statsTop)
+	statsTop = 2
+	assert.Equal(t, "7:3, 5:1, ... 1 more", topIDs(map[id.TriceID]int{5: 1, 6: 1, 7: 3}))
+}
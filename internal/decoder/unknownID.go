@@ -0,0 +1,103 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rokath/trice/internal/id"
+)
+
+// maxUnknownFrames is the max count of kept trices with unknown ID. If more arrive, the oldest are dropped.
+var maxUnknownFrames = 1000
+
+// unknownFrame is a trice with an ID not found in the ID list.
+type unknownFrame struct {
+	id     id.TriceID // trice ID
+	b      []byte     // trice bytes including header and CRC trailer
+	offset int64      // input stream offset
+	time   time.Time  // receive time
+}
+
+// unknownTrice handles a trice with ID LastTriceID not in the ID list, which has count data bytes and cycle counter cycle.
+// cycle is -1 for short sub-encoding trices, which have no cycle counter.
+//
+// An unknown ID is often only newer than the ID list. So, if the decoder is in sync and the trice structure is valid,
+// a placeholder line is returned and exactly this trice is skipped. The trice bytes are kept and re-rendered, when
+// the ID list gets the ID, see rerender. Otherwise the unknown ID is treated as a sync loss.
+// The structure of a trice with cycle counter is valid with the expected cycle and zero padding bytes, because an unknown
+// ID together with a cycle gap points more to garbage than to a new ID. A short sub-encoding trice has no structure to check,
+// so only its CRC trailer, if any, makes it valid.
+func (p *Flex) unknownTrice(count, cycle int) (n int, err error) {
+	valid := p.crc
+	if -1 != cycle {
+		valid = p.cycleKnown && cycle == 0xff&(p.cycle+1)
+	}
+	if !p.aligned || !valid {
+		return p.outOfSync("unknown triceID", fmt.Sprintf("%5d", LastTriceID))
+	}
+	size := p.triceSize(count)
+	if len(p.iBuf) < size+p.trailer {
+		return // wait
+	}
+	hdr := 4
+	if p.longCount {
+		hdr = 8
+	}
+	for _, x := range p.iBuf[hdr+count : size] {
+		if 0 != x {
+			return p.outOfSync("unknown triceID", fmt.Sprintf("%5d", LastTriceID))
+		}
+	}
+	f := unknownFrame{LastTriceID, append([]byte{}, p.iBuf[:size+p.trailer]...), p.pos, time.Now()}
+	p.lutMutex.RLock()
+	p.lutLen = len(p.lut)
+	p.lutMutex.RUnlock()
+	if len(p.unknown) == maxUnknownFrames {
+		p.unknown = p.unknown[1:]
+	}
+	p.unknown = append(p.unknown, f)
+	if -1 != cycle {
+		p.cycle = cycle
+	}
+	n = copy(p.b, fmt.Sprintln("wrn:unknown ID", f.id, fmt.Sprintf("[%d bytes: %s]", len(f.b), strings.Trim(fmt.Sprint(f.b), "[]"))))
+	p.rub4(count)
+	return
+}
+
+// rerender returns the first kept unknown ID trice, which is decodable now, because the ID list was reloaded.
+// It returns 0, if there is none. The ID list is checked only after a size change.
+func (p *Flex) rerender() (n int) {
+	if 0 == len(p.unknown) {
+		return
+	}
+	p.lutMutex.RLock()
+	lutLen := len(p.lut)
+	p.lutMutex.RUnlock()
+	if lutLen == p.lutLen {
+		return
+	}
+	for i, f := range p.unknown {
+		p.lutMutex.RLock()
+		_, ok := p.lut[f.id]
+		p.lutMutex.RUnlock()
+		if !ok {
+			continue
+		}
+		p.unknown = append(p.unknown[:i], p.unknown[i+1:]...)
+		q := NewFlexDecoder(p.lut, p.lutMutex, bytes.NewReader(f.b), p.endian).(*Flex) // a separate decoder keeps the cycle counter
+		q.pack2, q.crc, q.stats = p.pack2, p.crc, nil
+		q.aligned = true
+		e, _ := q.ReadEvent(p.b)
+		s := fmt.Sprintln("wrn:re-rendered unknown ID", f.id, "received", f.time.Format("15:04:05.000000"), "at offset", f.offset)
+		p.ev, p.rendered = e, true
+		p.ev.Offset = f.offset
+		return copy(p.b, s+e.Text)
+	}
+	p.lutLen = lutLen // nothing decodable anymore for this ID list
+	return
+}
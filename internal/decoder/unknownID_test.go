@@ -0,0 +1,79 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package decoder
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rokath/trice/internal/id"
+	"github.com/tj/assert"
+)
+
+func TestUnknownID(t *testing.T) {
+	lu := tilLookUp(t)
+	m := new(sync.RWMutex)
+	in := []byte{1, 124, 227, 255, 0, 0, 4, 0}     // TRICE16_2, cycle 1
+	in = append(in, 2, 4, 18, 143, 7, 0, 8, 0)     // unknown ID 123456, count 4, cycle 2
+	in = append(in, 3, 124, 227, 255, 2, 0, 8, 0)  // TRICE16_2, cycle 3
+	in = append(in, 9, 4, 18, 143, 7, 0, 8, 0)     // unknown ID 123456 with cycle gap
+	in = append(in, 10, 124, 227, 255, 3, 0, 8, 0) // TRICE16_2, cycle 10
+	dec := NewFlexDecoder(lu, m, bytes.NewReader(in), littleEndian)
+	act := readAll(dec)
+	assert.Equal(t, `MSG: triceFifoMaxDepth = 4, select = 0\n`, act[0])
+	assert.Equal(t, "wrn:unknown ID 123456 [8 bytes: 2 4 18 143 7 0 8 0]\n", act[1])
+	assert.Equal(t, `MSG: triceFifoMaxDepth = 8, select = 2\n`, act[2]) // no cycle warning
+	assert.True(t, strings.HasPrefix(act[3], "error: unknown triceID 123456 ignoring first byte"), act[3])
+	assert.True(t, strings.HasSuffix(act[len(act)-1], `MSG: triceFifoMaxDepth = 8, select = 3\n`))
+
+	m.Lock()
+	lu[123456] = id.TriceFmt{Type: "TRICE16_2", Strg: `new:%d, %d\n`}
+	m.Unlock()
+	act = readAll(dec)
+	assert.Equal(t, 1, len(act))
+	assert.True(t, strings.HasPrefix(act[0], "wrn:re-rendered unknown ID 123456 received "), act[0])
+	assert.True(t, strings.HasSuffix(act[0], " at offset 8\n"+`new:8, 7\n`), act[0])
+	assert.Nil(t, readAll(dec)) // re-rendered only once
+}
+
+func TestUnknownIDEvent(t *testing.T) {
+	lu := tilLookUp(t)
+	m := new(sync.RWMutex)
+	in := []byte{1, 124, 227, 255, 0, 0, 4, 0, 2, 4, 18, 143, 7, 0, 8, 0}
+	dec := NewFlexDecoder(lu, m, bytes.NewReader(in), littleEndian)
+	e := readEvents(t, dec)
+	assert.Equal(t, 2, len(e))
+	assert.Equal(t, id.TriceID(123456), e[1].ID)
+	assert.Equal(t, "", e[1].Type)
+
+	m.Lock()
+	lu[123456] = id.TriceFmt{Type: "TRICE16_2", Strg: `new:%d, %d\n`}
+	m.Unlock()
+	e = readEvents(t, dec)
+	assert.Equal(t, 1, len(e))
+	assert.Equal(t, id.TriceID(123456), e[0].ID)
+	assert.Equal(t, "TRICE16_2", e[0].Type)
+	assert.Equal(t, []interface{}{int16(8), int16(7)}, e[0].Values)
+	assert.Equal(t, 2, e[0].Cycle)
+	assert.Equal(t, int64(8), e[0].Offset)
+}
+
+func TestUnknownIDShort(t *testing.T) {
+	lu := tilLookUp(t)
+	unknown := []byte{1, 2, 3, 4} // short sub-encoding, ID 1027
+	in := []byte{1, 124, 227, 255, 0, 0, 4, 0}
+	in = append(in, unknown...)
+	act := readAll(NewFlexDecoder(lu, new(sync.RWMutex), bytes.NewReader(in), littleEndian))
+	assert.True(t, strings.HasPrefix(act[1], "error: unknown triceID  1027"), act[1]) // no structure to check
+
+	in = []byte{1, 124, 227, 255, 0, 0, 4, 0, 84, 115, 0, 0}
+	in = append(in, unknown...)
+	in = append(in, crc8(unknown), 0, 0, 0)
+	act = readAll(NewFlexCRCDecoder(lu, new(sync.RWMutex), bytes.NewReader(in), littleEndian))
+	assert.Equal(t, `MSG: triceFifoMaxDepth = 4, select = 0\n`, act[0])
+	assert.True(t, strings.HasPrefix(act[1], "wrn:unknown ID 1027 [8 bytes: 1 2 3 4 "), act[1])
+	assert.Equal(t, 2, len(act))
+}
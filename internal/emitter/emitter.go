@@ -51,13 +51,22 @@ var (
 	// Autostart if set, starts an additional trice instance as displayserver.
 	Autostart bool
 
+	// ServerKeyboard if set, lets the displayserver read command lines from its keyboard for a trice log -keybcmd instance.
+	ServerKeyboard bool
+
 	// TestTableMode is set externally to avoid Prefix overwrite
 	TestTableMode bool
 
 	// NextLine is set true as help for decoder.TestTableMode, where it is clreared at line start.
 	NextLine bool
+
+	// dynamicSource is set by SetPrefix, when the "source:" prefix needs to be replaced at each line start.
+	dynamicSource bool
 )
 
+// sourcePrefix is the Prefix start replaced by the source name.
+const sourcePrefix = "source:"
+
 // LineWriter is the common interface for output devices.
 // The string slice `line` contains all string parts of one line including prefix and suffix.
 // The last string part is without newline char and must be handled by the output device.
@@ -83,14 +92,17 @@ func newLineWriter() (lwD LineWriter) {
 	if true == DisplayRemote {
 		var p *RemoteDisplay
 		if true == Autostart {
-			p = NewRemoteDisplay(baseName(), "-logfile "+cage.Name)
+			params := "-logfile " + cage.Name
+			if ServerKeyboard {
+				params += " -keybcmd"
+			}
+			p = NewRemoteDisplay(baseName(), params)
 		} else {
 			p = NewRemoteDisplay()
 		}
 		//p.ErrorFatal()
 		msg.FatalOnErr(p.Err)
 		lwD = p
-		// keybcmd.ReadInput()
 	} else {
 		lwD = NewColorDisplay(ColorPalette)
 	}
@@ -112,10 +124,15 @@ func New() *TriceLineComposer {
 }
 
 // SetPrefix changes "source:" to e.g., "JLINK:".
+// For UDP ports "source:" is kept and replaced at each line start with the sender address of the received datagram.
 func SetPrefix() {
-	defaultPrefix := "source:"
-	if strings.HasPrefix(Prefix, defaultPrefix) {
-		Prefix = receiver.Port + ":" + Prefix[len(defaultPrefix):]
+	dynamicSource = false
+	if strings.HasPrefix(Prefix, sourcePrefix) {
+		if receiver.IsUDP(receiver.Port) {
+			dynamicSource = true
+			return
+		}
+		Prefix = receiver.Port + ":" + Prefix[len(sourcePrefix):]
 	} else if Prefix == "off" || Prefix == "none" {
 		Prefix = ""
 	}
@@ -6,6 +6,8 @@ package emitter
 import (
 	"strings"
 	"time"
+
+	"github.com/rokath/trice/internal/receiver"
 )
 
 // SyncPacketPattern is used if a sync packet arrives
@@ -19,16 +21,26 @@ type TriceLineComposer struct {
 	suffix          string
 	Line            []string // line collector
 	err             error
+	dynamicSource   bool // "source:" in prefix is replaced at each line start
 }
 
 // newLineComposer constructs log lines according to these rules:...
 // It provides an io.StringWriter interface which is used for the reception of (trice) strings.
 // It uses lw for writing the generated lines.
 func newLineComposer(lw LineWriter) *TriceLineComposer {
-	p := &TriceLineComposer{lw, TimestampFormat, Prefix, Suffix, make([]string, 0, 4096), nil} // not more than 4096 strings per line expected
+	p := &TriceLineComposer{lw, TimestampFormat, Prefix, Suffix, make([]string, 0, 4096), nil, dynamicSource} // not more than 4096 strings per line expected
 	return p
 }
 
+// linePrefix returns the prefix for a new line.
+// A "source:" prefix is replaced by the actual source here if it changes during runtime, like the sender address for UDP.
+func (p *TriceLineComposer) linePrefix() string {
+	if p.dynamicSource && strings.HasPrefix(p.prefix, sourcePrefix) {
+		return receiver.Source() + ":" + p.prefix[len(sourcePrefix):]
+	}
+	return p.prefix
+}
+
 // timestamp returns local time as string according var p.timeStampFormat
 func (p *TriceLineComposer) timestamp() string {
 	var s string
@@ -92,13 +104,14 @@ func (p *TriceLineComposer) WriteString(s string) (n int, err error) {
 	// If a string was already started and gets completed with a following WriteString call,
 	// it keeps its original timestamp, but if following lines inside s they get a new timestamp.
 	ts := p.timestamp()
+	prefix := p.linePrefix()
 	for _, sx := range ss {
 		if 0 == len(p.Line) && 0 < lineEndCount { // start new line && and complete line
-			p.Line = append(p.Line, ts, p.prefix, sx, p.suffix)
+			p.Line = append(p.Line, ts, prefix, sx, p.suffix)
 			p.completeLine()
 			lineEndCount--
 		} else if 0 == len(p.Line) && 0 == lineEndCount { // start new line
-			p.Line = append(p.Line, ts, p.prefix, sx)
+			p.Line = append(p.Line, ts, prefix, sx)
 			if 0 == len(sx) { // A new line with an empty string was started.
 				// This could cause unwanted timestamp offsets if the next line is significantly delayed.
 				emptyLine = true
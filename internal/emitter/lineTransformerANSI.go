@@ -85,6 +85,15 @@ func isChannel(ch string) bool {
 	return false
 }
 
+// Channel returns the lower case channel info of s like "err" for "ERR:something" or "" if s does not start with a channel.
+func Channel(s string) string {
+	sc := strings.SplitN(s, ":", 2)
+	if len(sc) < 2 || !isChannel(sc[0]) {
+		return ""
+	}
+	return strings.ToLower(sc[0])
+}
+
 // colorize prefixes s with an ansi color code according to this conditions:
 // If p.colorPalette is "off", do nothing.
 // If p.colorPalette is "none" remove only lower case channel info "col:"
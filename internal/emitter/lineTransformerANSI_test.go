@@ -69,3 +69,10 @@ func Test1writeLine(t *testing.T) {
 	eq := strings.Join([]string{"M:msg", "I:Info", "wrn:End"}, "")
 	assert.Equal(t, []string{ep, eq}, lw.lines)
 }
+
+func TestChannel(t *testing.T) {
+	assert.Equal(t, "msg", Channel("MSG: triceFifoMaxDepth = %d"))
+	assert.Equal(t, "rd_", Channel("rd_:%d"))
+	assert.Equal(t, "", Channel("abc:de"))
+	assert.Equal(t, "", Channel("msg"))
+}
@@ -80,6 +80,12 @@ func (p *RemoteDisplay) writeLine(line []string) {
 	p.Err = p.PtrRPC.Call("Server.WriteLine", line, nil) // TODO: Change to "Server.WriteLine"
 }
 
+// ReadCommands returns the command lines typed in the display server window since the last call.
+func (p *RemoteDisplay) ReadCommands() (lines []string, err error) {
+	err = p.PtrRPC.Call("Server.ReadCommands", []int64{0}, &lines) // if 1st param nil -> gob: cannot encode nil value
+	return
+}
+
 // startServer starts a display server with the filename exe (if not already running).
 func (p *RemoteDisplay) startServer() {
 	var cmd *exec.Cmd
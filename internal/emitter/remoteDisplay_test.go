@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math/rand"
+	"net"
+	"net/rpc"
 	"os"
 	"runtime"
 	"strings"
@@ -62,3 +64,38 @@ func readLines(filename string) (lines []string, err error) {
 	lines = strings.Split(stringContent, "\n")
 	return
 }
+
+func TestReadCommands(t *testing.T) {
+	srv := new(Server)
+	srv.readKeyboard(strings.NewReader("help\nlog on\n"))
+	rs := rpc.NewServer()
+	assert.Nil(t, rs.Register(srv))
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer func() { assert.Nil(t, ln.Close()) }()
+	go rs.Accept(ln)
+
+	p := &RemoteDisplay{}
+	p.PtrRPC, err = rpc.Dial("tcp", ln.Addr().String())
+	assert.Nil(t, err)
+	defer func() { assert.Nil(t, p.PtrRPC.Close()) }()
+	lines, err := p.ReadCommands()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"help", "log on"}, lines)
+	lines, err = p.ReadCommands()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(lines))
+}
+
+func TestReadKeyboardLimit(t *testing.T) {
+	srv := new(Server)
+	var b strings.Builder
+	for i := 0; i < maxCommands+5; i++ {
+		fmt.Fprintln(&b, i)
+	}
+	srv.readKeyboard(strings.NewReader(b.String()))
+	var lines []string
+	assert.Nil(t, srv.ReadCommands(nil, &lines))
+	assert.Equal(t, maxCommands, len(lines))
+	assert.Equal(t, "5", lines[0])
+}
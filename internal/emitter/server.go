@@ -6,10 +6,14 @@
 package emitter
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/rpc"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/rokath/trice/pkg/cage"
@@ -24,7 +28,9 @@ import (
 
 // Server is the RPC struct for registered server dunctions
 type Server struct {
-	Display ColorDisplay // todo: LineWriter?
+	Display  ColorDisplay // todo: LineWriter?
+	mu       sync.Mutex   // protects commands
+	commands []string     // typed command lines not fetched yet
 }
 
 // WriteLine is the exported server method for string display, if trice tool acts as display server.
@@ -43,6 +49,33 @@ func (p *Server) ColorPalette(s []string, reply *int64) error {
 	return nil
 }
 
+// ReadCommands is called remotely to fetch the command lines typed in the display server window.
+// The trice log instance sends them to the target.
+func (p *Server) ReadCommands(_ []int64, reply *[]string) error {
+	p.mu.Lock()
+	*reply = p.commands
+	p.commands = nil
+	p.mu.Unlock()
+	return nil
+}
+
+// maxCommands is the count of typed command lines kept for ReadCommands. Older lines are dropped.
+const maxCommands = 100
+
+// readKeyboard collects the lines from r for ReadCommands until r ends.
+// When nobody fetches them, only the last maxCommands lines are kept.
+func (p *Server) readKeyboard(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		p.mu.Lock()
+		if maxCommands <= len(p.commands) {
+			p.commands = p.commands[1:]
+		}
+		p.commands = append(p.commands, scanner.Text())
+		p.mu.Unlock()
+	}
+}
+
 // LogSetFlags is called remotely to ...
 func (p *Server) LogSetFlags(f []int64, r *int64) error {
 	flags := int(f[0])
@@ -92,6 +125,9 @@ func ScDisplayServer() error {
 	srv := new(Server)
 	srv.Display = *NewColorDisplay(ColorPalette)
 	msg.OnErr(rpc.Register(srv))
+	if ServerKeyboard {
+		go srv.readKeyboard(os.Stdin)
+	}
 	var err error
 	listener, err = net.Listen("tcp", a)
 	if nil != err {
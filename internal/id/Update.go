@@ -14,6 +14,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/rokath/trice/pkg/cformat"
 	"github.com/rokath/trice/pkg/msg"
 )
 
@@ -23,7 +24,8 @@ const (
 	patSourceFile = "(\\.c|\\.h|\\.cc|\\.cpp|\\.hpp)$"
 
 	// patTrice matches any TRICE name variant https://regex101.com/r/jJGKvL/1, The (?i) says case insensitive
-	patTypNameTRICE = `(?i)(\b((TRICE((_S|0)|((8|16|32|64)(_[1-8])?))))i*\b)`
+	// The float variants TRICE32F and TRICE64F transmit the float and double bits. TRICE_S and TRICE_B transmit a string or a byte buffer.
+	patTypNameTRICE = `(?i)(\b((TRICE((_S|_B|0)|((8|16|32F?|64F?)(_[1-8])?))))i*\b)`
 
 	// patFmtString is a regex matching the first format string inside trice
 	patFmtString = `"(.*)"`
@@ -37,11 +39,8 @@ const (
 	// patAnyTriceStart finds a starting trice with opening '(': https://regex101.com/r/wPuT4M/1
 	patAnyTriceStart = patTypNameTRICE + `\s*\(`
 
-	// patNextFormatSpezifier is a regex to find next format specifier in a string (exclude %%*)
-	patNextFormatSpezifier = `(?:^|[^%])(%[0-9\.#]*(b|c|d|u|x|X|o|f))`
-
 	// patTriceNoLen finds next `TRICEn` without length specifier: https://regex101.com/r/oKjjic/1
-	patTriceNoLen = `(?i)(\bTRICE(8|16|32|64)i?\b)`
+	patTriceNoLen = `(?i)(\bTRICE(8|16|32F?|64F?)i?\b)`
 
 	patID = `\s*\b(I|i)d\b\s*`
 
@@ -58,7 +57,6 @@ var (
 	matchNbID                  = regexp.MustCompile(patNbID)
 	matchTypNameTRICE          = regexp.MustCompile(patTypNameTRICE)
 	matchFmtString             = regexp.MustCompile(patFmtString)
-	matchNextFormatSpezifier   = regexp.MustCompile(patNextFormatSpezifier)
 	matchFullAnyTrice          = regexp.MustCompile(patFullAnyTrice)
 	matchTriceNoLen            = regexp.MustCompile(patTriceNoLen)
 	matchIDInsideTrice         = regexp.MustCompile(patIDInsideTrice)
@@ -88,7 +86,7 @@ func updateParamCountAndID0(text string, extendMacroName bool) (string, bool) {
 		if extendMacroName {
 			locNoLen := matchTriceNoLen.FindStringIndex(trice) // find the next TRICE no len location in trice
 			if nil != locNoLen {                               // need to add len to trice name
-				n := FormatSpecifierCount(triceC)
+				n := FormatSpecifierCount(matchFmtString.FindStringSubmatch(triceC)[1]) // only the format string, the parameters could contain a modulo operator
 				triceNameNoLen := triceC[locNoLen[0]:locNoLen[1]]
 				triceNameWithLen := addFormatSpecifierCount(triceNameNoLen, n)
 				triceC = strings.Replace(triceC, triceNameNoLen, triceNameWithLen, 1) // insert _n
@@ -121,20 +119,9 @@ func updateParamCountAndID0(text string, extendMacroName bool) (string, bool) {
 	}
 }
 
-// FormatSpecifierCount parses s for format specifier and returns the found count.
-func FormatSpecifierCount(s string) (count int) {
-	xs := "any"
-	for "" != xs {
-		lo := matchNextFormatSpezifier.FindStringIndex(s)
-		xs = matchNextFormatSpezifier.FindString(s)
-		if "" != xs { // found
-			count++
-			s = s[lo[1]:]
-		} else {
-			xs = ""
-		}
-	}
-	return
+// FormatSpecifierCount parses the format string s like the trice tool and returns the count of consumed values, see cformat.Parse.
+func FormatSpecifierCount(s string) int {
+	return cformat.Parse(s).Count()
 }
 
 // addFormatSpecifierCount extends s or si with _n or _ni and returns it as sl
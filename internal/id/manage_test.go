@@ -102,3 +102,19 @@ func TestLutFileTransfer(t *testing.T) {
 	act := fmt.Sprint(rd)
 	assert.Equal(t, exp, act)
 }
+
+func TestAddFmtCount(t *testing.T) {
+	lu := TriceIDLookUp{
+		1: {Type: "TRICE32", Strg: "%i %ld"},
+		2: {Type: "TRICE16i", Strg: "%-5d %+d"},
+		3: {Type: "TRICE8", Strg: "%*d"},
+		4: {Type: "TRICE32", Strg: "%hhx %p"},
+		5: {Type: "TRICE8_1", Strg: "%d"},
+	}
+	lu.AddFmtCount()
+	assert.Equal(t, "TRICE32_2", lu[1].Type)
+	assert.Equal(t, "TRICE16_2i", lu[2].Type)
+	assert.Equal(t, "TRICE8_2", lu[3].Type)
+	assert.Equal(t, "TRICE32_2", lu[4].Type)
+	assert.Equal(t, "TRICE8_1", lu[5].Type)
+}
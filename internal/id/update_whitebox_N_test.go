@@ -780,3 +780,21 @@ func checkTestTable(t *testing.T, tt []struct{ text, exp string }, extend bool)
 		assert.Equal(t, x.exp, act)
 	}
 }
+
+func TestInsertParamCountAndIDFloat(t *testing.T) {
+	tt := []struct{ text, exp string }{
+		{`... TRICE32F ( "%f", x); ...`, `... TRICE32F_1 ( Id(0), "%f", x); ...`},
+		{`... trice32F ( "%e %.3g", x, y); ...`, `... trice32F_2 ( Id(0), "%e %.3g", x, y); ...`},
+		{`... TRICE64Fi ( "%F %G", x, y); ...`, `... TRICE64F_2i ( Id(0), "%F %G", x, y); ...`},
+		{`... TRICE64F_1 ( "%f", x); ...`, `... TRICE64F_1 ( Id(0), "%f", x); ...`},
+	}
+	checkTestTable(t, tt, true)
+}
+
+func TestInsertIDBuffer(t *testing.T) {
+	tt := []struct{ text, exp string }{
+		{`... TRICE_B ( "rx: %02x\n", buf, len); ...`, `... TRICE_B ( Id(0), "rx: %02x\n", buf, len); ...`},
+		{`... TRICE_B ( "%xxd", buf, 17); ...`, `... TRICE_B ( Id(0), "%xxd", buf, 17); ...`},
+	}
+	checkTestTable(t, tt, true)
+}
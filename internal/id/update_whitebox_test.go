@@ -244,6 +244,21 @@ func TestInsertSharedIDs0WithParamCount(t *testing.T) {
 	checkList(t, true, 10000, 20000, 10, 99, tt, eList, false)
 }
 
+// The parameter count follows the C printf rules of the trice tool, see cformat.Parse.
+func TestInsertSharedIDsCFormats(t *testing.T) {
+	SearchMethod = "upward"
+	tt := testTable{
+		{`... TRICE32( "%i %ld\n", a, b ); ...`, `... TRICE32_2( Id( 40000), "%i %ld\n", a, b ); ...`, true, true},
+		{`... TRICE16( "%-5d %+d\n", a, b ); ...`, `... TRICE16_2( Id( 40001), "%-5d %+d\n", a, b ); ...`, true, true},
+		{`... TRICE8( "%*d\n", w, a ); ...`, `... TRICE8_2( Id( 40002), "%*d\n", w, a ); ...`, true, true},
+		{`... TRICE32i( "%hhx %p\n", a, p ); ...`, `... TRICE32_2i( Id( 40003), "%hhx %p\n", a, p ); ...`, true, true},
+		{`... TRICE16( "%%d %d\n", a%2 ); ...`, `... TRICE16_1( Id( 40004), "%%d %d\n", a%2 ); ...`, true, true},
+	}
+	eList := `map[40000:{TRICE32_2 %i %ld\n} 40001:{TRICE16_2 %-5d %+d\n} 40002:{TRICE8_2 %*d\n} 40003:{TRICE32_2i %hhx %p\n} 40004:{TRICE16_1 %%d %d\n}]
+`
+	checkList(t, true, 10000, 20000, 40000, 50000, tt, eList, true)
+}
+
 func TestInsertSharedIDsInvalid0(t *testing.T) {
 	SearchMethod = "downward"
 	tt := testTable{
@@ -271,6 +286,8 @@ var tryOkSet = []idCheck{
 	{`TRICE0(Id( 59 ), "tt" )`, "Id( 59 )", 59, true, TriceFmt{"TRICE0", "tt"}},
 	{`trice0(Id(59), "tt" )`, "Id(59)", 59, true, TriceFmt{"trice0", "tt"}},
 	{`trice64_2(Id(59), "%d,%x", -3, -4 )`, "Id(59)", 59, true, TriceFmt{"trice64_2", "%d,%x"}},
+	{`TRICE32F_2i(Id(59), "%f,%g", x, y )`, "Id(59)", 59, true, TriceFmt{"TRICE32F_2i", "%f,%g"}},
+	{`TRICE_B(Id(59), "rx:\n%xxd\n", buf, len )`, "Id(59)", 59, true, TriceFmt{"TRICE_B", `rx:\n%xxd\n`}},
 }
 
 func TestTriceParseOK(t *testing.T) {
@@ -4,47 +4,208 @@
 // Package keybcmd is responsible for interpreting user commanmdline and executing commands
 package keybcmd
 
-//  func loopAction(reader *bufio.Reader, ipa, ipp string) {
-//  	fmt.Print("-> ")
-//  	text, _ := reader.ReadString('\n')
-//  	// convert CRLF to LF
-//  	e := "\n"
-//  	if runtime.GOOS == "windows" {
-//  		e = "\r\n"
-//  	}
-//  	text = strings.Replace(text, e, "", -1) // Linux "\n" !
-//
-//  	switch text {
-//  	case "q", "quit":
-//  		os.Exit(0)
-//  	case "h", "help":
-//  		fmt.Println("h|help                   - this text")
-//  		fmt.Println("exitServer|serverExit    - kill server")
-//  		fmt.Println("q|quit                   - end program")
-//  	case "sd", "stopServer", "serverStop":
-//  		err := emitter.ScShutdownRemoteDisplayServer(1, ipa, ipp)
-//  		if nil != err {
-//  			fmt.Println(err)
-//  		}
-//  	default:
-//  		fmt.Printf("Unknown command '%s' - use 'help'\n", text)
-//  	}
-//  }
-
-//  // ReadInput expects user input from terminal
-//  func ReadInput() { // https://tutorialedge.net/golang/reading-console-input-golang/
-//  	reader := bufio.NewReader(os.Stdin)
-//  	keyboardInput(reader)
-//  }
-
-//  // keyboardInput expects input from reader
-//  func keyboardInput(reader *bufio.Reader) { // https://tutorialedge.net/golang/reading-console-input-golang/
-//  	fmt.Println("Simple Shell")
-//  	fmt.Println("------------")
-//
-//  	go func() {
-//  		for {
-//  			loopAction(reader, emitter.IPAddr, emitter.IPPort)
-//  		}
-//  	}() // https://stackoverflow.com/questions/16008604/why-add-after-closure-body-in-golang
-//  }
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rokath/trice/internal/com"
+	"github.com/rokath/trice/internal/emitter"
+)
+
+var (
+	// Verbose gives more information on output if set. The value is injected from main packages.
+	Verbose bool
+
+	// Keyboard is true when command lines are read from stdin during logging. It is set as command line parameter.
+	Keyboard bool
+
+	// CmdFile is a file with command lines, which are executed after each connect. It is set as command line parameter.
+	CmdFile string
+
+	// EOL is the line ending appended to each command line sent to the target, "CRLF", "CR", "LF" or "none". It is set as command line parameter.
+	EOL = "CRLF"
+
+	// target is the destination for the commands and the reset. It is nil without writable connection.
+	target io.Writer
+
+	// m protects target.
+	m sync.Mutex
+
+	// errNoTarget is returned when a command line cannot be sent.
+	errNoTarget = errors.New("no writable connection to the target, command line ignored")
+
+	// AtQuit, if not nil, is called before the program ends with the quit command.
+	AtQuit func()
+
+	// exit ends the program. It is a variable for testing.
+	exit = os.Exit
+)
+
+// SetTarget sets w as destination for the command lines. Use nil when the connection was closed.
+func SetTarget(w io.Writer) {
+	m.Lock()
+	target = w
+	m.Unlock()
+}
+
+// lineEnd returns the line ending according to EOL.
+func lineEnd() (string, error) {
+	switch strings.ToUpper(EOL) {
+	case "CRLF":
+		return "\r\n", nil
+	case "CR":
+		return "\r", nil
+	case "LF":
+		return "\n", nil
+	case "NONE", "":
+		return "", nil
+	}
+	return "", fmt.Errorf("unknown line ending %s, expecting CRLF|CR|LF|none", EOL)
+}
+
+// Send writes s with the line ending to the target.
+func Send(s string) error {
+	e, err := lineEnd()
+	if nil != err {
+		return err
+	}
+	m.Lock()
+	defer m.Unlock()
+	if nil == target {
+		return errNoTarget
+	}
+	if Verbose {
+		fmt.Printf("sending %q\n", s+e)
+	}
+	_, err = target.Write([]byte(s + e))
+	return err
+}
+
+// Execute interprets the command line s. Lines starting with "!" are local commands, all other lines are sent to the target.
+// A line starting with "!!" is sent to the target without the first "!".
+func Execute(s string) error {
+	s = strings.TrimRight(s, "\r\n")
+	if !strings.HasPrefix(s, "!") || strings.HasPrefix(s, "!!") {
+		return Send(strings.TrimPrefix(s, "!"))
+	}
+	f := strings.Fields(s[1:])
+	if 0 == len(f) {
+		return help()
+	}
+	switch f[0] {
+	case "q", "quit":
+		if nil != AtQuit {
+			AtQuit()
+		}
+		exit(0)
+	case "h", "help":
+		return help()
+	case "reset":
+		signal := com.Reset
+		if 2 == len(f) {
+			signal = f[1]
+		}
+		return reset(signal)
+	case "sd", "stopServer", "serverStop":
+		return emitter.ScShutdownRemoteDisplayServer(1)
+	case "sleep":
+		if 2 != len(f) {
+			return fmt.Errorf("expecting a duration like '!sleep 500ms'")
+		}
+		d, err := time.ParseDuration(f[1])
+		if nil != err {
+			return err
+		}
+		time.Sleep(d)
+	default:
+		return fmt.Errorf("unknown command '%s' - use '!help'", s)
+	}
+	return nil
+}
+
+// reset resets the target with signal, see com.ResetTarget.
+func reset(signal string) error {
+	if "" == signal {
+		return fmt.Errorf("no reset signal, use '!reset DTR|RTS|DTR+RTS|break' or -reset")
+	}
+	m.Lock()
+	defer m.Unlock()
+	s, ok := target.(com.Signaler)
+	if !ok {
+		return fmt.Errorf("no serial port connection, reset ignored")
+	}
+	return com.ResetTarget(s, signal)
+}
+
+// help prints the local commands.
+func help() error {
+	fmt.Println("!h|!help                 - this text")
+	fmt.Println("!reset [signal]          - reset the target with signal DTR|RTS|DTR+RTS|break, default is the -reset value")
+	fmt.Println("!sd|!stopServer          - kill display server")
+	fmt.Println("!sleep duration          - wait, example: '!sleep 500ms'")
+	fmt.Println("!q|!quit                 - end program")
+	fmt.Println("!!text                   - send '!text' to the target")
+	fmt.Println("text                     - send 'text' to the target")
+	return nil
+}
+
+// ReadInput executes the command lines from r until r ends.
+// Errors are printed and do not stop the reading.
+func ReadInput(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if err := Execute(scanner.Text()); nil != err {
+			fmt.Println(err)
+		}
+	}
+	return scanner.Err()
+}
+
+// ExecuteFile executes the command lines in file fn. Empty lines and lines starting with "#" are ignored.
+// It stops at the first error or without error, when stop is closed. Then the connection, the lines are for, is gone.
+func ExecuteFile(fn string, stop <-chan struct{}) error {
+	f, err := os.Open(fn)
+	if nil != err {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		s := strings.TrimSpace(scanner.Text())
+		if "" == s || strings.HasPrefix(s, "#") {
+			continue
+		}
+		select {
+		case <-stop:
+			return nil
+		default:
+		}
+		if err := Execute(s); nil != err {
+			return fmt.Errorf("%s: %v", fn, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// ReadRemote executes the command lines typed in the display server window, which are fetched from p every interval.
+// It returns when the display server is not reachable anymore.
+func ReadRemote(p *emitter.RemoteDisplay, interval time.Duration) error {
+	for {
+		lines, err := p.ReadCommands()
+		if nil != err {
+			return err
+		}
+		for _, s := range lines {
+			if err := Execute(s); nil != err {
+				fmt.Println(err)
+			}
+		}
+		time.Sleep(interval)
+	}
+}
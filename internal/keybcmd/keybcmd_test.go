@@ -4,9 +4,114 @@
 // whitebox test
 package keybcmd
 
-import "testing"
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
 
-func TestDummy(t *testing.T) {
+	"github.com/rokath/trice/internal/com"
+	"github.com/tj/assert"
+)
+
+func TestExecute(t *testing.T) {
+	var b bytes.Buffer
+	SetTarget(&b)
+	defer SetTarget(nil)
+	assert.Nil(t, Execute("help\n"))
+	assert.Nil(t, Execute("!!x"))
+	assert.Nil(t, Execute("!sleep 1ms"))
+	assert.NotNil(t, Execute("!sleep"))
+	assert.NotNil(t, Execute("!unknown"))
+	assert.Equal(t, "help\r\n!x\r\n", b.String())
+
+	defer func(s string) { EOL = s }(EOL)
+	b.Reset()
+	EOL = "LF"
+	assert.Nil(t, Execute("ls"))
+	EOL = "none"
+	assert.Nil(t, Execute("ls"))
+	assert.Equal(t, "ls\nls", b.String())
+	EOL = "xx"
+	assert.NotNil(t, Execute("ls"))
+}
+
+// serialStub is a target accepting commands and line signals.
+type serialStub struct {
+	bytes.Buffer
+	signals []string
+}
+
+func (p *serialStub) SetDTR(on bool) error {
+	p.signals = append(p.signals, fmt.Sprint("DTR=", on))
+	return nil
+}
+
+func (p *serialStub) SetRTS(on bool) error {
+	p.signals = append(p.signals, fmt.Sprint("RTS=", on))
+	return nil
+}
+
+func (p *serialStub) Break(d time.Duration) error {
+	p.signals = append(p.signals, "break")
+	return nil
+}
+
+func TestReset(t *testing.T) {
+	defer func(s string, d time.Duration) { com.Reset, com.ResetTime = s, d }(com.Reset, com.ResetTime)
+	com.Reset, com.ResetTime = "", time.Millisecond
+	var p serialStub
+	SetTarget(&p)
+	defer SetTarget(nil)
+	assert.NotNil(t, Execute("!reset"))
+	assert.Nil(t, Execute("!reset break"))
+	com.Reset = "RTS"
+	assert.Nil(t, Execute("!reset"))
+	assert.Equal(t, []string{"break", "RTS=false", "RTS=true", "RTS=false"}, p.signals)
+	assert.Equal(t, 0, p.Len())
+
+	SetTarget(&bytes.Buffer{}) // no serial port
+	assert.NotNil(t, Execute("!reset"))
+}
+
+func TestExecuteNoTarget(t *testing.T) {
+	SetTarget(nil)
+	assert.Equal(t, errNoTarget, Execute("help"))
+}
+
+func TestQuit(t *testing.T) {
+	defer func(f func(int)) { exit = f }(exit)
+	code := -1
+	exit = func(c int) { code = c }
+	assert.Nil(t, ReadInput(strings.NewReader("!q\n")))
+	assert.Equal(t, 0, code)
+}
+
+func TestExecuteFile(t *testing.T) {
+	var b bytes.Buffer
+	SetTarget(&b)
+	defer SetTarget(nil)
+	dir, err := ioutil.TempDir("", "keybcmd")
+	assert.Nil(t, err)
+	defer func() { assert.Nil(t, os.RemoveAll(dir)) }()
+	fn := filepath.Join(dir, "cmds.txt")
+	assert.Nil(t, ioutil.WriteFile(fn, []byte("# init\n\nlog on\r\n!sleep 1ms\nrate 10\n"), 0644))
+	assert.Nil(t, ExecuteFile(fn, nil))
+	assert.Equal(t, "log on\r\nrate 10\r\n", b.String())
+
+	b.Reset()
+	stop := make(chan struct{})
+	close(stop)
+	assert.Nil(t, ExecuteFile(fn, stop)) // connection gone
+	assert.Equal(t, "", b.String())
+
+	assert.Nil(t, ioutil.WriteFile(fn, []byte("!bad\nnot sent\n"), 0644))
+	assert.NotNil(t, ExecuteFile(fn, nil))
+	assert.NotNil(t, ExecuteFile(filepath.Join(dir, "missing.txt"), nil))
 }
 
 //  // stimulate injects keys to the loop action and returns the captured output as byte slice.
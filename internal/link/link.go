@@ -8,22 +8,31 @@
 package link
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/pkg/errors"
 	"github.com/rokath/trice/pkg/msg"
 )
 
 var (
 	// Verbose gives mor information on output if set. The value is injected from main packages.
 	Verbose bool
+
+	// PollInterval is the wait time for new data at the temporary logfile end.
+	PollInterval = 100 * time.Millisecond
 )
 
+// stderrMax is the max kept stderr output size of the RTT logger.
+const stderrMax = 1024
+
 // Device is the RTT logger reader interface.
 type Device struct {
 	Exec      string   // linkBinary is the RTT logger executable .
@@ -35,7 +44,35 @@ type Device struct {
 	tempLogFileName   string
 	tempLogFileHandle *os.File
 	Err               error
-	Done              chan bool
+	Done              chan bool // closed when the RTT logger process ended
+
+	stderr   tailBuffer // last RTT logger stderr output
+	exitErr  error      // RTT logger exit result, valid after Done is closed
+	killOnce sync.Once
+}
+
+// tailBuffer keeps the last stderrMax written bytes. It is safe for concurrent use.
+type tailBuffer struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+// Write appends b and drops the oldest bytes if stderrMax is exceeded.
+func (p *tailBuffer) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.buf = append(p.buf, b...)
+	if len(p.buf) > stderrMax {
+		p.buf = p.buf[len(p.buf)-stderrMax:]
+	}
+	return len(b), nil
+}
+
+// String returns the kept bytes as trimmed string.
+func (p *tailBuffer) String() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return string(bytes.TrimSpace(p.buf))
 }
 
 // NewDevice creates an instance of RTT ReadCloser of type Port.
@@ -46,7 +83,7 @@ func NewDevice(port, arguments string) *Device {
 	case "JLINK", "J-LINK":
 		p.Exec = "JLinkRTTLogger"
 		p.Lib = "JLinkARM"
-	case "STLINK, ST-LINK":
+	case "STLINK", "ST-LINK":
 		p.Exec = "stRttLogger"
 		p.Lib = "libusb-1.0"
 	}
@@ -81,20 +118,53 @@ func (p *Device) ErrorFatal() {
 }
 
 // Read() is part of the exported interface io.ReadCloser. It reads a slice of bytes.
-func (p *Device) Read(b []byte) (int, error) {
-	return p.tempLogFileHandle.Read(b)
+// The temporary logfile is followed: If the RTT logger is behind, Read waits PollInterval and returns 0, nil.
+// An ended RTT logger process is reported as error, so that the caller can re-open the device.
+func (p *Device) Read(b []byte) (n int, err error) {
+	n, err = p.tempLogFileHandle.Read(b)
+	if io.EOF != err {
+		return
+	}
+	select {
+	case <-p.Done:
+		n, err = p.tempLogFileHandle.Read(b) // bytes written just before the process end
+		if 0 < n {
+			return n, nil
+		}
+		return 0, p.exitError()
+	case <-time.After(PollInterval):
+		return 0, nil
+	}
+}
+
+// exitError returns the RTT logger end as error, containing its last stderr output.
+func (p *Device) exitError() error {
+	s := fmt.Sprint(p.Exec, " ended")
+	if nil != p.exitErr {
+		s += fmt.Sprint(": ", p.exitErr)
+	}
+	if e := p.stderr.String(); "" != e {
+		s += fmt.Sprint(", stderr: ", e)
+	}
+	return fmt.Errorf("%s", s)
 }
 
 // Close is part of the exported interface io.ReadCloser. It ends the connection.
+// The RTT logger process is killed if still running and waited for. Then the temporary logfile is removed.
 func (p *Device) Close() error {
 	if Verbose {
 		fmt.Println("Closing link device.")
 	}
-	// CTRL-C sends SIGTERM also to the started command. It closes the temporary file and terminates itself.
-	// Todo: If trice is terminated not with CTRL-C kill automatically.
-	// p.Err = errors.Wrap(p.Err, p.cmd.Process.Kill().Error())
-	// p.Err = errors.Wrap(p.Err, p.tempLogFileHandle.Close().Error())
-	p.Err = errors.Wrap(p.Err, os.Remove(p.tempLogFileName).Error())
+	if nil != p.cmd && nil != p.cmd.Process {
+		p.killOnce.Do(func() {
+			_ = p.cmd.Process.Kill() // an error here means the process ended already
+			<-p.Done
+		})
+	}
+	if nil != p.tempLogFileHandle {
+		_ = p.tempLogFileHandle.Close() // it was already closed, if Open failed
+	}
+	p.Err = os.Remove(p.tempLogFileName)
 	return p.Err
 }
 
@@ -108,16 +178,27 @@ func (p *Device) Open() error {
 		}
 	}
 	p.cmd = exec.Command(p.Exec, p.args...)
-
+	p.cmd.Stderr = &p.stderr
 	if Verbose {
 		p.cmd.Stdout = os.Stdout
-		p.cmd.Stderr = os.Stderr
+		p.cmd.Stderr = io.MultiWriter(os.Stderr, &p.stderr)
 	}
+
 	p.Err = p.cmd.Start()
-	p.ErrorFatal()
+	if nil != p.Err {
+		p.Err = fmt.Errorf("linkCmd = %s, linkLib = %s <--- PATH ok? %v", p.Exec, p.Lib, p.Err)
+		return p.Err
+	}
+	p.Done = make(chan bool)
+	go func() {
+		p.exitErr = p.cmd.Wait()
+		close(p.Done)
+	}()
 
 	p.tempLogFileHandle, p.Err = os.Open(p.tempLogFileName) // Open() opens a file with read only flag.
-	p.ErrorFatal()
+	if nil != p.Err {
+		return p.Err // The caller is expected to Close, what ends the started process.
+	}
 
 	// p.watchLogfile() // todo: make it working well
 	if Verbose {
@@ -5,8 +5,94 @@
 package link_test
 
 import (
+	"io"
+	"os"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/rokath/trice/internal/link"
+	"github.com/stretchr/testify/assert"
 )
 
-func TestDummy(t *testing.T) {
+// TestLoggerStub is no real test. It is started by the tests as stub executable standing in for JLinkRTTLogger.
+// Like JLinkRTTLogger it writes into the file given as last parameter.
+// Environment variable TRICE_LINK_STUB controls, if it exits after writing or keeps running.
+func TestLoggerStub(t *testing.T) {
+	mode := os.Getenv("TRICE_LINK_STUB")
+	if "" == mode {
+		return
+	}
+	f, err := os.OpenFile(os.Args[len(os.Args)-1], os.O_WRONLY|os.O_APPEND, 0)
+	if nil != err {
+		os.Exit(2)
+	}
+	_, _ = f.Write([]byte{1, 2, 3})
+	time.Sleep(300 * time.Millisecond) // let the reader reach the file end
+	_, _ = f.Write([]byte{4, 5})
+	_ = f.Close()
+	if "exit" == mode {
+		_, _ = os.Stderr.WriteString("stub: target lost\n")
+		os.Exit(1)
+	}
+	time.Sleep(time.Minute) // is killed on Close
+}
+
+// newStubDevice returns a link device using the test binary as RTT logger stub in mode.
+func newStubDevice(t *testing.T, mode string) *link.Device {
+	assert.Nil(t, os.Setenv("TRICE_LINK_STUB", mode))
+	p := link.NewDevice("JLINK", "-test.run=TestLoggerStub")
+	p.Exec = os.Args[0]
+	return p
+}
+
+// readAll reads from p until 5 bytes arrived or an error occurs.
+func readAll(p *link.Device) (act []byte, err error) {
+	b := make([]byte, 100)
+	for len(act) < 5 && nil == err {
+		var n int
+		n, err = p.Read(b)
+		act = append(act, b[:n]...)
+	}
+	return
+}
+
+func TestDeviceProcessExit(t *testing.T) {
+	defer func() { assert.Nil(t, os.Unsetenv("TRICE_LINK_STUB")) }()
+	p := newStubDevice(t, "exit")
+	assert.Nil(t, p.Open())
+	act, err := readAll(p) // the file end is followed and not reported as io.EOF
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3, 4, 5}, act)
+	for nil == err { // wait for the process end
+		_, err = p.Read(make([]byte, 100))
+	}
+	assert.True(t, io.EOF != err)
+	assert.True(t, strings.Contains(err.Error(), "stub: target lost"), err.Error())
+	assert.Nil(t, p.Close())
+}
+
+func TestDeviceClose(t *testing.T) {
+	defer func() { assert.Nil(t, os.Unsetenv("TRICE_LINK_STUB")) }()
+	p := newStubDevice(t, "stay")
+	assert.Nil(t, p.Open())
+	act, err := readAll(p)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3, 4, 5}, act)
+	start := time.Now()
+	assert.Nil(t, p.Close()) // kills the still running stub
+	assert.True(t, time.Since(start) < 10*time.Second)
+	select {
+	case <-p.Done:
+	default:
+		t.Fail()
+	}
+}
+
+func TestDeviceNoExecutable(t *testing.T) {
+	p := link.NewDevice("STLINK", "")
+	assert.Equal(t, "stRttLogger", p.Exec)
+	p.Exec = "notExistingRTTLogger"
+	assert.NotNil(t, p.Open())
+	assert.Nil(t, p.Close())
 }
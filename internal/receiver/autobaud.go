@@ -0,0 +1,52 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver
+
+import (
+	"io"
+	"time"
+
+	"github.com/rokath/trice/internal/com"
+)
+
+// IsSerial returns true if Port is a serial port.
+func IsSerial() bool {
+	return isSerial(Port)
+}
+
+// Sample opens the serial Port with baud rate baud and returns the bytes received within duration d.
+// It is used for the baud rate detection. The other line settings come from PortArguments, a baud rate there is ignored, see com.ParseArgs.
+func Sample(baud int, d time.Duration) ([]byte, error) {
+	com.Baud = baud
+	rc, err := NewReadCloser(Port, PortArguments)
+	if nil != err {
+		return nil, err
+	}
+	var (
+		b    []byte
+		done = make(chan struct{})
+		stop = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		buf := make([]byte, 4096)
+		for {
+			n, e := rc.Read(buf)
+			b = append(b, buf[:n]...)
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if nil != e && io.EOF != e { // a tarm read timeout is io.EOF
+				return
+			}
+		}
+	}()
+	time.Sleep(d)
+	close(stop)
+	err = rc.Close() // ends a blocking read
+	<-done
+	return b, err
+}
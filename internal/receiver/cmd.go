@@ -0,0 +1,111 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// cmdReadCloser is a receiver starting a command and reading its stdout, usable for vendor probe tools or custom bridges.
+type cmdReadCloser struct {
+	cmd    *exec.Cmd
+	stdout io.ReadCloser
+	once   sync.Once
+	err    error // command exit result
+}
+
+// splitCommandLine splits s at spaces into command and arguments.
+// Parts containing spaces can be enclosed in double or single quotes, like "my tool" -f 'C:/my path/board.cfg'.
+func splitCommandLine(s string) (args []string, err error) {
+	var arg strings.Builder
+	var quote rune
+	var inArg bool
+	for _, c := range s {
+		switch {
+		case 0 != quote && c == quote:
+			quote = 0
+		case 0 != quote:
+			arg.WriteRune(c)
+		case '"' == c || '\'' == c:
+			quote = c
+			inArg = true
+		case ' ' == c || '\t' == c:
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(c)
+			inArg = true
+		}
+	}
+	if 0 != quote {
+		return nil, fmt.Errorf("missing closing %c in %s", quote, s)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return
+}
+
+// newCmdReadCloser starts the command line commandLine and returns a reader for its stdout.
+// The command stderr output is passed to the trice stderr.
+func newCmdReadCloser(commandLine string) (io.ReadCloser, error) {
+	args, err := splitCommandLine(commandLine)
+	if nil != err {
+		return nil, err
+	}
+	if 0 == len(args) {
+		return nil, errors.New("no command in args")
+	}
+	p := &cmdReadCloser{cmd: exec.Command(args[0], args[1:]...)}
+	p.cmd.Stderr = os.Stderr
+	if p.stdout, err = p.cmd.StdoutPipe(); nil != err {
+		return nil, err
+	}
+	if err = p.cmd.Start(); nil != err {
+		return nil, err
+	}
+	if Verbose {
+		fmt.Println("Started", p.cmd.Args, "with process ID", p.cmd.Process.Pid)
+	}
+	return p, nil
+}
+
+// wait waits for the command end once and returns its exit result.
+func (p *cmdReadCloser) wait() error {
+	p.once.Do(func() {
+		p.err = p.cmd.Wait()
+	})
+	return p.err
+}
+
+// Read reads from the command stdout.
+// A successful command end is reported as io.EOF, a failed one as error containing the exit result.
+func (p *cmdReadCloser) Read(b []byte) (n int, err error) {
+	n, err = p.stdout.Read(b)
+	if io.EOF == err {
+		if e := p.wait(); nil != e {
+			err = fmt.Errorf("command %s ended: %v", p.cmd.Path, e)
+		}
+	}
+	return
+}
+
+// Close ends the command, if still running.
+func (p *cmdReadCloser) Close() error {
+	if Verbose {
+		fmt.Println("Closing command", p.cmd.Args)
+	}
+	_ = p.cmd.Process.Kill() // an error here means the command ended already
+	_ = p.wait()             // the exit result after a kill is no error
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver_test
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/rokath/trice/internal/receiver"
+	"github.com/tj/assert"
+)
+
+// TestCmdHelper is no real test. It is the command started by TestCMD.
+func TestCmdHelper(t *testing.T) {
+	switch os.Getenv("TRICE_CMD_HELPER") {
+	case "1":
+		_, _ = os.Stdout.Write([]byte{1, 2, 3})
+		os.Exit(0)
+	case "2":
+		os.Exit(2)
+	}
+}
+
+func TestCMD(t *testing.T) {
+	assert.Nil(t, os.Setenv("TRICE_CMD_HELPER", "1"))
+	defer func() { assert.Nil(t, os.Unsetenv("TRICE_CMD_HELPER")) }()
+	rc, err := receiver.NewReadCloser("CMD", `"`+os.Args[0]+`" -test.run=TestCmdHelper`)
+	assert.Nil(t, err)
+	b := make([]byte, 100)
+	n, err := io.ReadAtLeast(rc, b, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, b[:n])
+	n, err = rc.Read(b)
+	assert.True(t, 0 == n)
+	assert.True(t, io.EOF == err) // successful command end
+	assert.Nil(t, rc.Close())
+
+	assert.Nil(t, os.Setenv("TRICE_CMD_HELPER", "2"))
+	rc, err = receiver.NewReadCloser("CMD", `"`+os.Args[0]+`" -test.run=TestCmdHelper`)
+	assert.Nil(t, err)
+	n, err = rc.Read(b)
+	assert.True(t, 0 == n)
+	assert.NotNil(t, err)
+	assert.True(t, io.EOF != err) // failed command end
+	assert.Contains(t, err.Error(), "exit status 2")
+	assert.Nil(t, rc.Close())
+}
+
+func TestCMDErrors(t *testing.T) {
+	_, err := receiver.NewReadCloser("CMD", "")
+	assert.NotNil(t, err)
+	_, err = receiver.NewReadCloser("CMD", "notExistingCommand -x")
+	assert.NotNil(t, err)
+	_, err = receiver.NewReadCloser("CMD", `"notExistingCommand -x`)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,30 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	tt := []struct {
+		s   string
+		exp []string
+	}{
+		{"openocd -f board.cfg", []string{"openocd", "-f", "board.cfg"}},
+		{"  tool\t-a  -b ", []string{"tool", "-a", "-b"}},
+		{`"my tool" -f 'C:/my path/board.cfg'`, []string{"my tool", "-f", "C:/my path/board.cfg"}},
+		{`tool -s "" x`, []string{"tool", "-s", "", "x"}},
+		{"", nil},
+	}
+	for _, x := range tt {
+		act, err := splitCommandLine(x.s)
+		assert.Nil(t, err)
+		assert.Equal(t, x.exp, act)
+	}
+	_, err := splitCommandLine(`tool "-x`)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+var (
+	// Follow keeps reading a FILE port at its end like "tail -f", for files still growing.
+	Follow bool
+
+	// FollowInterval is the poll interval for new data at the file end when following.
+	FollowInterval = 100 * time.Millisecond
+)
+
+// fileReadCloser is a receiver for raw binary trice data stored in a file, like a JLinkRTTLogger output file.
+type fileReadCloser struct {
+	f      *os.File
+	follow bool
+}
+
+// newFileReadCloser opens the file name for reading.
+// If follow is true, reaching the file end is not reported as io.EOF.
+func newFileReadCloser(name string, follow bool) (io.ReadCloser, error) {
+	f, err := os.Open(name)
+	if nil != err {
+		return nil, err
+	}
+	if Verbose {
+		fmt.Println("Reading", name, "follow:", follow)
+	}
+	return &fileReadCloser{f, follow}, nil
+}
+
+// Read reads from the file.
+// When following, at the file end Read waits FollowInterval and returns 0, nil.
+// If the file got truncated in the meantime, reading restarts at the file begin.
+func (p *fileReadCloser) Read(b []byte) (n int, err error) {
+	n, err = p.f.Read(b)
+	if !p.follow || io.EOF != err {
+		return
+	}
+	time.Sleep(FollowInterval)
+	err = p.rewindIfTruncated()
+	return
+}
+
+// rewindIfTruncated sets the read position to the file begin, if the file is now shorter than the read position.
+func (p *fileReadCloser) rewindIfTruncated() error {
+	pos, err := p.f.Seek(0, io.SeekCurrent)
+	if nil != err {
+		return err
+	}
+	fi, err := p.f.Stat()
+	if nil != err {
+		return err
+	}
+	if fi.Size() < pos {
+		if Verbose {
+			fmt.Println(p.f.Name(), "truncated, reading from begin")
+		}
+		_, err = p.f.Seek(0, io.SeekStart)
+	}
+	return err
+}
+
+// Close closes the file.
+func (p *fileReadCloser) Close() error {
+	return p.f.Close()
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/rokath/trice/internal/receiver"
+	"github.com/tj/assert"
+)
+
+// tempFile returns the name of a new temporary file with content b.
+func tempFile(t *testing.T, b []byte) string {
+	f, err := ioutil.TempFile("", "trice*.bin")
+	assert.Nil(t, err)
+	_, err = f.Write(b)
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+	return f.Name()
+}
+
+func TestFILE(t *testing.T) {
+	fn := tempFile(t, []byte{1, 2, 3})
+	defer func() { assert.Nil(t, os.Remove(fn)) }()
+	rc, err := receiver.NewReadCloser("FILE", fn)
+	assert.Nil(t, err)
+	b, err := ioutil.ReadAll(rc)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, b)
+	n, err := rc.Read(b)
+	assert.True(t, 0 == n)
+	assert.Equal(t, io.EOF, err)
+	assert.Nil(t, rc.Close())
+}
+
+func TestFILEFollow(t *testing.T) {
+	fn := tempFile(t, []byte{1, 2, 3})
+	defer func() { assert.Nil(t, os.Remove(fn)) }()
+	receiver.Follow = true
+	defer func() { receiver.Follow = false }()
+	rc, err := receiver.NewReadCloser("FILE", fn)
+	assert.Nil(t, err)
+	b := make([]byte, 100)
+	n, err := rc.Read(b)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, b[:n])
+	n, err = rc.Read(b) // file end
+	assert.Nil(t, err)
+	assert.True(t, 0 == n)
+
+	f, err := os.OpenFile(fn, os.O_APPEND|os.O_WRONLY, 0)
+	assert.Nil(t, err)
+	_, err = f.Write([]byte{4, 5})
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+	n, err = rc.Read(b)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{4, 5}, b[:n])
+
+	assert.Nil(t, os.Truncate(fn, 0)) // file rotated
+	assert.Nil(t, ioutil.WriteFile(fn, []byte{6}, 0644))
+	n, err = rc.Read(b) // detects truncation
+	assert.Nil(t, err)
+	assert.True(t, 0 == n)
+	n, err = rc.Read(b)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{6}, b[:n])
+	assert.Nil(t, rc.Close())
+}
+
+func TestFILENotExisting(t *testing.T) {
+	_, err := receiver.NewReadCloser("FILE", "notExistingFile.bin")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,162 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rokath/trice/pkg/msg"
+)
+
+// A raw log file starts with rawLogMagic followed by records.
+// Each record is one read chunk: 8 bytes arrival time in nanoseconds since 1970, 4 bytes chunk length and the chunk bytes.
+// All numbers are little endian.
+const rawLogMagic = "TRB1"
+
+var (
+	// RawLog is the file name for recording the undecoded input bytes. If empty, nothing is recorded.
+	RawLog string
+
+	// ReplaySpeed is the replay speed for port "REPLAY" like "1x" for original timing, "10x" for 10 times faster or "max" for no waiting.
+	ReplaySpeed = "1x"
+
+	// errNoRawLog is returned for files not starting with rawLogMagic.
+	errNoRawLog = errors.New("no trice raw log file")
+
+	// errTruncated is returned for a raw log file ending inside a record.
+	errTruncated = errors.New("truncated trice raw log record")
+)
+
+// NewRawLogFile creates the file name and writes the raw log file header into it.
+func NewRawLogFile(name string) (io.WriteCloser, error) {
+	f, err := os.Create(name)
+	if nil != err {
+		return nil, err
+	}
+	if _, err = f.Write([]byte(rawLogMagic)); nil != err {
+		msg.OnErr(f.Close())
+		return nil, err
+	}
+	return f, nil
+}
+
+// rawLogger is a ReadCloser which writes all read bytes with arrival time as records into a raw log.
+type rawLogger struct {
+	r io.ReadCloser
+	w io.Writer
+}
+
+// NewRawLogger returns a ReadCloser `in` which is internally using reader `from`.
+// All bytes read from `from` are additionally recorded into `to`, which is expected to be created with NewRawLogFile.
+func NewRawLogger(from io.ReadCloser, to io.Writer) (in io.ReadCloser) {
+	return &rawLogger{from, to}
+}
+
+func (p *rawLogger) Read(buf []byte) (count int, err error) {
+	count, err = p.r.Read(buf)
+	if 0 < count {
+		h := make([]byte, 12)
+		binary.LittleEndian.PutUint64(h, uint64(time.Now().UnixNano()))
+		binary.LittleEndian.PutUint32(h[8:], uint32(count))
+		_, e := p.w.Write(append(h, buf[:count]...))
+		msg.OnErr(e) // a recording problem should not stop logging
+	}
+	return
+}
+
+// Framed forwards the Framer property of the internally used reader.
+func (p *rawLogger) Framed() bool {
+	f, ok := p.r.(Framer)
+	return ok && f.Framed()
+}
+
+// Close closes the internally used reader. The raw log itself is closed by its creator.
+func (p *rawLogger) Close() error { return p.r.Close() }
+
+// parseSpeed converts s like "1x", "10x", "0.5x" or "max" into a speed factor. "max" gives 0.
+func parseSpeed(s string) (float64, error) {
+	if "max" == strings.ToLower(s) {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(s), "x"), 64)
+	if nil != err || f <= 0 {
+		return 0, fmt.Errorf("invalid replay speed %s, use for example 1x, 10x or max", s)
+	}
+	return f, nil
+}
+
+// replayReadCloser delivers the bytes of a raw log with the recorded timing.
+type replayReadCloser struct {
+	f       *os.File
+	r       *bufio.Reader
+	speed   float64 // 0 means as fast as possible
+	last    int64   // arrival time of the previous record
+	pending []byte  // record bytes not delivered yet
+}
+
+// newReplayReadCloser opens the raw log file name for replay with speed like ReplaySpeed.
+func newReplayReadCloser(name, speed string) (io.ReadCloser, error) {
+	s, err := parseSpeed(speed)
+	if nil != err {
+		return nil, err
+	}
+	f, err := os.Open(name)
+	if nil != err {
+		return nil, err
+	}
+	p := &replayReadCloser{f: f, r: bufio.NewReader(f), speed: s}
+	magic := make([]byte, len(rawLogMagic))
+	if _, err = io.ReadFull(p.r, magic); nil != err || rawLogMagic != string(magic) {
+		msg.OnErr(f.Close())
+		return nil, fmt.Errorf("%s: %v", name, errNoRawLog)
+	}
+	return p, nil
+}
+
+// Read delivers the next record bytes after waiting the recorded time distance to the previous record divided by speed.
+// At the raw log end Read returns io.EOF.
+func (p *replayReadCloser) Read(b []byte) (n int, err error) {
+	if 0 == len(p.pending) {
+		if err = p.next(); nil != err {
+			return
+		}
+	}
+	n = copy(b, p.pending)
+	p.pending = p.pending[n:]
+	return
+}
+
+// next reads the next record into p.pending and waits accordingly its arrival time.
+func (p *replayReadCloser) next() error {
+	h := make([]byte, 12)
+	if _, err := io.ReadFull(p.r, h); nil != err {
+		if io.ErrUnexpectedEOF == err {
+			return errTruncated
+		}
+		return err // io.EOF at record start is the regular end
+	}
+	t := int64(binary.LittleEndian.Uint64(h))
+	p.pending = make([]byte, binary.LittleEndian.Uint32(h[8:]))
+	if _, err := io.ReadFull(p.r, p.pending); nil != err {
+		return errTruncated
+	}
+	if 0 < p.speed && 0 < p.last && p.last < t {
+		time.Sleep(time.Duration(float64(t-p.last) / p.speed))
+	}
+	p.last = t
+	return nil
+}
+
+// Close closes the raw log file.
+func (p *replayReadCloser) Close() error {
+	return p.f.Close()
+}
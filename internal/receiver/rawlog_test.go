@@ -0,0 +1,122 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/rokath/trice/internal/receiver"
+	"github.com/tj/assert"
+)
+
+// chunkReader delivers one chunk with each Read after a delay.
+type chunkReader struct {
+	chunks [][]byte
+	delay  time.Duration
+}
+
+func (p *chunkReader) Read(b []byte) (n int, err error) {
+	if 0 == len(p.chunks) {
+		return 0, io.EOF
+	}
+	time.Sleep(p.delay)
+	n = copy(b, p.chunks[0])
+	p.chunks = p.chunks[1:]
+	return
+}
+
+func (p *chunkReader) Close() error { return nil }
+
+// record writes chunks with delay into a new raw log file and returns its name.
+func record(t *testing.T, chunks [][]byte, delay time.Duration) string {
+	f, err := ioutil.TempFile("", "trice*.trb")
+	assert.Nil(t, err)
+	fn := f.Name()
+	assert.Nil(t, f.Close())
+	w, err := receiver.NewRawLogFile(fn)
+	assert.Nil(t, err)
+	rc := receiver.NewRawLogger(&chunkReader{chunks, delay}, w)
+	b, err := ioutil.ReadAll(rc)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(b))
+	assert.Nil(t, rc.Close())
+	assert.Nil(t, w.Close())
+	return fn
+}
+
+func TestReplay(t *testing.T) {
+	fn := record(t, [][]byte{{1, 2}, {3}, {4, 5}}, 50*time.Millisecond)
+	defer func() { assert.Nil(t, os.Remove(fn)) }()
+
+	rc, err := receiver.NewReadCloser("REPLAY", fn) // receiver.ReplaySpeed is "1x"
+	assert.Nil(t, err)
+	b := make([]byte, 1) // smaller than a chunk
+	var act []byte
+	start := time.Now()
+	for {
+		n, err := rc.Read(b)
+		act = append(act, b[:n]...)
+		if io.EOF == err {
+			break
+		}
+		assert.Nil(t, err)
+	}
+	assert.True(t, 100*time.Millisecond <= time.Since(start)) // 2 recorded chunk distances
+	assert.Equal(t, []byte{1, 2, 3, 4, 5}, act)
+	assert.Nil(t, rc.Close())
+
+	receiver.ReplaySpeed = "max"
+	defer func() { receiver.ReplaySpeed = "1x" }()
+	rc, err = receiver.NewReadCloser("REPLAY", fn)
+	assert.Nil(t, err)
+	start = time.Now()
+	act, err = ioutil.ReadAll(rc)
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) < 50*time.Millisecond)
+	assert.Equal(t, []byte{1, 2, 3, 4, 5}, act)
+	assert.Nil(t, rc.Close())
+}
+
+func TestReplayErrors(t *testing.T) {
+	fn := record(t, [][]byte{{1, 2, 3, 4, 5}}, 0)
+	defer func() { assert.Nil(t, os.Remove(fn)) }()
+	receiver.ReplaySpeed = "fast"
+	_, err := receiver.NewReadCloser("REPLAY", fn)
+	assert.NotNil(t, err)
+	receiver.ReplaySpeed = "1x"
+
+	_, err = receiver.NewReadCloser("REPLAY", "notExistingFile.trb")
+	assert.NotNil(t, err)
+
+	f, err := ioutil.TempFile("", "trice*.bin")
+	assert.Nil(t, err)
+	defer func() { assert.Nil(t, os.Remove(f.Name())) }()
+	_, err = f.Write([]byte{1, 2, 3, 4, 5})
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+	_, err = receiver.NewReadCloser("REPLAY", f.Name()) // no raw log
+	assert.NotNil(t, err)
+}
+
+func TestReplayTruncated(t *testing.T) {
+	fn := record(t, [][]byte{{1, 2, 3}, {4, 5}}, 0)
+	defer func() { assert.Nil(t, os.Remove(fn)) }()
+	fi, err := os.Stat(fn)
+	assert.Nil(t, err)
+	assert.Nil(t, os.Truncate(fn, fi.Size()-1)) // second record incomplete
+
+	receiver.ReplaySpeed = "max"
+	defer func() { receiver.ReplaySpeed = "1x" }()
+	rc, err := receiver.NewReadCloser("REPLAY", fn)
+	assert.Nil(t, err)
+	act, err := ioutil.ReadAll(rc)
+	assert.Equal(t, []byte{1, 2, 3}, act)
+	assert.NotNil(t, err)
+	assert.True(t, io.EOF != err)
+	assert.Nil(t, rc.Close())
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"os"
 	"strings"
 
 	"github.com/rokath/trice/internal/com"
@@ -17,6 +18,9 @@ import (
 )
 
 var (
+	// Verbose gives mor information on output if set. The value is injected from main packages.
+	Verbose bool
+
 	// ShowInputBytes displays incoming bytes if set true.
 	ShowInputBytes bool
 
@@ -25,6 +29,9 @@ var (
 
 	// PortArguments are the trice receiver device specific arguments.
 	PortArguments string
+
+	// serialPort is the serial port opened last, used for the line error counters. It is nil for other port types.
+	serialPort com.COMport
 )
 
 // scanBytes assumes in s whitespace separated decimal numbers between 0 and 255 and returns them in buf
@@ -48,30 +55,77 @@ func scanBytes(s string) (buf []byte) {
 
 // NewReadCloser returns a ReadCloser for the specified port and its args.
 // err is nil on successful open.
-// When port is "COMn" args can be used to be "TARM" to use a different driver for dynamic testing.
+// When port is "usb:VID:PID[:serial]", the serial port with this USB data is used like a "COMn" port.
+// When port is "COMn" args can contain a line configuration like "115200,8,E,1,rtscts" and "TARM" to use a different driver for dynamic testing.
 // When port is "BUFFER", args is expected to be a byte sequence in the same format as for example coming from one of the other ports.
 // When port is "JLINK" args contains JLinkRTTLogger.exe specific parameters described inside UM08001_JLink.pdf.
 // When port is "STLINK" args has the same format as for "JLINK"
+// When port is "TCP4:host:port" or "TCP6:host:port", a TCP connection to host:port is established and args are ignored.
+// When port is "UDP:ip:port", "UDP4:ip:port" or "UDP6:ip:port", datagrams are received on ip:port. If args is "frame", each datagram is a frame.
+// When port is "FILE", args is the name of a file with raw trice bytes. If Follow is true, the file end is waited for new bytes.
+// When port is "REPLAY", args is the name of a raw log file, which is replayed with ReplaySpeed.
+// When port is "STDIN", the bytes are read from standard input, for example at the end of a pipeline. args are ignored.
+// When port is "FIFO:path", the bytes are read from the named pipe path. args are ignored.
+// When port is "CMD", args is a command line. The command is started and its stdout is read.
+// When port is "OPENOCD:host:port", the OpenOCD RTT server at host:port is used. args are ignored.
+// When port is "JLINKRTT" or "JLINKRTT:host[:port]", the J-Link RTT telnet server is used. args is the RTT channel number.
 func NewReadCloser(port, args string) (r io.ReadCloser, err error) {
-	switch port {
+	serialPort = nil
+	switch portType(port) {
+	case "TCP4", "TCP6":
+		r, err = newTCPReadCloser(splitNetPort(port))
+	case "UDP", "UDP4", "UDP6":
+		network, address := splitNetPort(port)
+		r, err = newUDPReadCloser(network, address, args)
 	case "JLINK", "STLINK", "J-LINK", "ST-LINK":
 		l := link.NewDevice(port, args)
-		if nil != l.Open() {
-			err = fmt.Errorf("can not open link device %s with args %s", port, args)
+		if e := l.Open(); nil != e {
+			msg.OnErr(l.Close())
+			return nil, fmt.Errorf("can not open link device %s with args %s: %v", port, args, e)
 		}
 		r = l
+	case "FILE":
+		r, err = newFileReadCloser(args, Follow)
+	case "REPLAY":
+		r, err = newReplayReadCloser(args, ReplaySpeed)
+	case "STDIN":
+		r = ioutil.NopCloser(os.Stdin) // closing is up to the process end
+	case "FIFO":
+		r, err = os.Open(strings.TrimPrefix(port, "FIFO:")) // blocks until a writer opens the pipe
+	case "OPENOCD":
+		_, address := splitNetPort(port)
+		r, err = newOpenOCDReadCloser(address)
+	case "JLINKRTT":
+		_, address := splitNetPort(port)
+		r, err = newJLinkRTTReadCloser(address, args)
+	case "CMD":
+		r, err = newCmdReadCloser(args)
 	case "BUFFER":
 		buf := scanBytes(args)
 		r = ioutil.NopCloser(bytes.NewBuffer(buf))
 	default: // assuming serial port
-		var c com.COMport   // interface type
-		if "TARM" == args { // for comparing dynamic behaviour
-			c = com.NewCOMPortTarm(port)
+		line, e := com.ParseArgs(args)
+		if nil != e {
+			return nil, e
+		}
+		if com.IsUSBPort(port) { // resolve on each connect, the name can change after re-enumeration
+			if port, e = com.FindUSBPort(port); nil != e {
+				return nil, e
+			}
+			if Verbose {
+				fmt.Println("Using serial port", port)
+			}
+		}
+		var c com.COMport // interface type
+		if line.Tarm {    // for comparing dynamic behaviour
+			c = com.NewCOMPortTarm(port, line)
 		} else {
-			c = com.NewCOMPortGoBugSt(port)
+			c = com.NewCOMPortGoBugSt(port, line)
 		}
 		if !c.Open() {
 			err = fmt.Errorf("can not open %s", port)
+		} else {
+			serialPort = c
 		}
 		r = c
 		return
@@ -79,6 +133,42 @@ func NewReadCloser(port, args string) (r io.ReadCloser, err error) {
 	return
 }
 
+// Finite returns true if the receiver Port delivers a limited amount of bytes, so that io.EOF means end of data.
+func Finite() bool {
+	switch portType(Port) {
+	case "BUFFER", "REPLAY", "STDIN", "FIFO", "CMD":
+		return true
+	case "FILE":
+		return !Follow
+	}
+	return false
+}
+
+// LineErrors returns the serial line error counters of the serial port opened last with NewReadCloser.
+// ok is false if the port is no serial port or the counters are not available, what is the case on other OS than Linux for example.
+func LineErrors() (le com.LineErrors, ok bool) {
+	c, isCounter := serialPort.(com.LineErrorCounter)
+	if !isCounter {
+		return
+	}
+	le, err := c.LineErrors()
+	return le, nil == err
+}
+
+// Writable returns true if the receiver Port can also send data to the target, what is the case for serial ports and TCP connections.
+func Writable() bool {
+	switch portType(Port) {
+	case "TCP4", "TCP6":
+		return true
+	}
+	return isSerial(Port)
+}
+
+// portType returns the port part in front of a first colon, like "TCP4" for "TCP4:localhost:2217".
+func portType(port string) string {
+	return strings.SplitN(port, ":", 2)[0]
+}
+
 // ////////////////////////////////////////////////////////////////////////////////////////////////
 // dynamic debug helper
 //
@@ -102,8 +192,14 @@ func (p *bytesViewer) Read(buf []byte) (count int, err error) {
 	return
 }
 
-// Close is needed to satify the ReadCloser interface.
-func (p *bytesViewer) Close() error { return nil }
+// Framed forwards the Framer property of the internally used reader.
+func (p *bytesViewer) Framed() bool {
+	f, ok := p.r.(Framer)
+	return ok && f.Framed()
+}
+
+// Close is needed to satify the ReadCloser interface. It closes the internally used reader.
+func (p *bytesViewer) Close() error { return p.r.Close() }
 
 //
 // ////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,47 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver
+
+import (
+	"github.com/rokath/trice/internal/com"
+)
+
+// usbSerial is the USB serial number of the serial Port. It is used to find the port again, if it re-appears with a different name.
+var usbSerial string
+
+// isSerial returns true if port is no other known port type, so it is assumed to be a serial port.
+func isSerial(port string) bool {
+	switch portType(port) {
+	case "TCP4", "TCP6", "UDP", "UDP4", "UDP6", "JLINK", "STLINK", "J-LINK", "ST-LINK",
+		"FILE", "REPLAY", "STDIN", "FIFO", "CMD", "OPENOCD", "JLINKRTT", "BUFFER":
+		return false
+	}
+	return true
+}
+
+// RememberPort keeps the USB serial number of a serial Port for Relocate.
+// It is called after a successful connect.
+func RememberPort() {
+	if !isSerial(Port) {
+		return
+	}
+	if sn := com.USBSerialNumber(Port); "" != sn {
+		usbSerial = sn
+	}
+}
+
+// Relocate assigns Port the name of the serial port with the remembered USB serial number,
+// if Port is not present but that port is, for example after re-plugging an USB serial adapter into a different USB socket.
+// It returns true if Port was changed.
+func Relocate() bool {
+	if "" == usbSerial || !isSerial(Port) || com.Exists(Port) {
+		return false
+	}
+	name := com.PortWithUSBSerialNumber(usbSerial)
+	if "" == name || name == Port {
+		return false
+	}
+	Port = name
+	return true
+}
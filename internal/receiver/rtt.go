@@ -0,0 +1,140 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rokath/trice/pkg/msg"
+)
+
+const (
+	// jlinkRTTPort is the default J-Link RTT telnet server port, opened by the J-Link GDB server or J-Link Commander.
+	jlinkRTTPort = "19021"
+
+	// jlinkBannerStart is the first line start of the text banner sent by the J-Link RTT telnet server on connect.
+	jlinkBannerStart = "SEGGER J-Link"
+
+	// jlinkBannerEnd is the last line start of the J-Link RTT telnet server banner.
+	jlinkBannerEnd = "Process:"
+)
+
+// BannerTimeout is the max wait time for the J-Link RTT telnet server banner after connect.
+var BannerTimeout = 500 * time.Millisecond
+
+// jlinkRTTReadCloser is a J-Link RTT telnet server client. It delivers the RTT channel bytes without the server banner.
+type jlinkRTTReadCloser struct {
+	tcpReadCloser
+	pending []byte // bytes received together with the banner
+}
+
+// rttAddress returns address with host "localhost" if the host is missing and port defaultPort if the port is missing.
+func rttAddress(address, defaultPort string) string {
+	if "" == address {
+		return net.JoinHostPort("localhost", defaultPort)
+	}
+	if _, _, err := net.SplitHostPort(address); nil == err {
+		if strings.HasPrefix(address, ":") {
+			return "localhost" + address
+		}
+		return address
+	}
+	return net.JoinHostPort(address, defaultPort) // address is host only
+}
+
+// rttChannel returns the RTT channel number given in args. "default" and "" mean channel 0.
+func rttChannel(args string) (int, error) {
+	if "" == args || "default" == args {
+		return 0, nil
+	}
+	ch, err := strconv.Atoi(args)
+	if nil != err || ch < 0 {
+		return 0, fmt.Errorf("invalid RTT channel %s", args)
+	}
+	return ch, nil
+}
+
+// newOpenOCDReadCloser connects to an OpenOCD RTT server started with "rtt server start port channel".
+// The RTT channel is selected on the OpenOCD side, so the byte stream is used as is.
+func newOpenOCDReadCloser(address string) (io.ReadCloser, error) {
+	if "" == address {
+		return nil, fmt.Errorf("missing OpenOCD RTT server port, example: OPENOCD:localhost:9090")
+	}
+	return newTCPReadCloser("tcp", rttAddress(address, ""))
+}
+
+// newJLinkRTTReadCloser connects to the J-Link RTT telnet server at address and selects the RTT channel given in args.
+// The channel selection config string needs to be sent immediately after connect, the server ignores it after 100ms.
+func newJLinkRTTReadCloser(address, args string) (io.ReadCloser, error) {
+	ch, err := rttChannel(args)
+	if nil != err {
+		return nil, err
+	}
+	conn, err := net.DialTimeout("tcp", rttAddress(address, jlinkRTTPort), DialTimeout)
+	if nil != err {
+		return nil, err
+	}
+	p := &jlinkRTTReadCloser{tcpReadCloser: tcpReadCloser{conn}}
+	if _, err = fmt.Fprintf(conn, "$$SEGGER_TELNET_ConfigStr=RTTCh;%d$$", ch); nil != err {
+		_ = conn.Close()
+		return nil, err
+	}
+	if err = p.skipBanner(); nil != err {
+		_ = conn.Close()
+		return nil, err
+	}
+	if Verbose {
+		fmt.Println("Connected to J-Link RTT server", conn.RemoteAddr(), "channel", ch)
+	}
+	return p, nil
+}
+
+// skipBanner reads the text banner the J-Link RTT telnet server sends on connect and keeps the following bytes.
+// If no banner arrives within BannerTimeout, all received bytes are kept.
+func (p *jlinkRTTReadCloser) skipBanner() error {
+	b := make([]byte, 1024)
+	var buf []byte
+	msg.OnErr(p.conn.SetReadDeadline(time.Now().Add(BannerTimeout)))
+	for {
+		n, err := p.conn.Read(b)
+		buf = append(buf, b[:n]...)
+		if e, ok := err.(net.Error); ok && e.Timeout() {
+			break
+		}
+		if nil != err {
+			return err
+		}
+		k := len(buf)
+		if len(jlinkBannerStart) < k {
+			k = len(jlinkBannerStart)
+		}
+		if jlinkBannerStart[:k] != string(buf[:k]) {
+			break // no banner
+		}
+		if i := bytes.Index(buf, []byte("\n"+jlinkBannerEnd)); 0 <= i {
+			if j := bytes.IndexByte(buf[i+1:], '\n'); 0 <= j {
+				buf = buf[i+1+j+1:] // banner end line complete
+				break
+			}
+		}
+	}
+	p.pending = buf
+	return p.conn.SetReadDeadline(time.Time{})
+}
+
+// Read delivers first the bytes received after the banner and then reads from the connection.
+func (p *jlinkRTTReadCloser) Read(b []byte) (n int, err error) {
+	if 0 < len(p.pending) {
+		n = copy(b, p.pending)
+		p.pending = p.pending[n:]
+		return
+	}
+	return p.tcpReadCloser.Read(b)
+}
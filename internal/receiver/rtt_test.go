@@ -0,0 +1,81 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver_test
+
+import (
+	"io"
+	"net"
+	"testing"
+
+	"github.com/rokath/trice/internal/receiver"
+	"github.com/tj/assert"
+)
+
+// flexBytes is a canned flexL trice.
+var flexBytes = []byte{2, 124, 227, 255, 0, 0, 4, 0}
+
+// fakeRTTServer accepts one connection, sends banner and then flexBytes in 2 parts.
+// The first received bytes are passed into cfg.
+func fakeRTTServer(t *testing.T, banner string, cfg chan string) string {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	assert.Nil(t, err)
+	go func() {
+		defer func() { _ = ln.Close() }()
+		conn, err := ln.Accept()
+		if nil != err {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		if nil != cfg {
+			b := make([]byte, 100)
+			n, _ := conn.Read(b)
+			cfg <- string(b[:n])
+		}
+		_, _ = conn.Write(append([]byte(banner), flexBytes[:3]...))
+		_, _ = conn.Write(flexBytes[3:])
+	}()
+	return ln.Addr().String()
+}
+
+// readFlexBytes reads len(flexBytes) from rc.
+func readFlexBytes(t *testing.T, rc io.ReadCloser) {
+	b := make([]byte, 100)
+	n, err := io.ReadAtLeast(rc, b, len(flexBytes))
+	assert.Nil(t, err)
+	assert.Equal(t, flexBytes, b[:n])
+	assert.Nil(t, rc.Close())
+}
+
+func TestJLINKRTT(t *testing.T) {
+	cfg := make(chan string, 1)
+	banner := "SEGGER J-Link V6.88a - Real time terminal output\r\nJ-Link OB-STM32F072-CortexM compiled Jan  7 2019 14:09:37 V1.0, SN=770806762\r\nProcess: JLinkGDBServerCLExe\r\n"
+	addr := fakeRTTServer(t, banner, cfg)
+	rc, err := receiver.NewReadCloser("JLINKRTT:"+addr, "1")
+	assert.Nil(t, err)
+	assert.Equal(t, "$$SEGGER_TELNET_ConfigStr=RTTCh;1$$", <-cfg)
+	readFlexBytes(t, rc)
+}
+
+func TestJLINKRTTNoBanner(t *testing.T) {
+	cfg := make(chan string, 1)
+	addr := fakeRTTServer(t, "", cfg)
+	rc, err := receiver.NewReadCloser("JLINKRTT:"+addr, "default")
+	assert.Nil(t, err)
+	assert.Equal(t, "$$SEGGER_TELNET_ConfigStr=RTTCh;0$$", <-cfg)
+	readFlexBytes(t, rc)
+}
+
+func TestJLINKRTTWrongChannel(t *testing.T) {
+	_, err := receiver.NewReadCloser("JLINKRTT:localhost", "x")
+	assert.NotNil(t, err)
+}
+
+func TestOPENOCD(t *testing.T) {
+	addr := fakeRTTServer(t, "", nil)
+	rc, err := receiver.NewReadCloser("OPENOCD:"+addr, "default")
+	assert.Nil(t, err)
+	readFlexBytes(t, rc)
+	_, err = receiver.NewReadCloser("OPENOCD", "default")
+	assert.NotNil(t, err)
+}
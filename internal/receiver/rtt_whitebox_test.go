@@ -0,0 +1,18 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func TestRttAddress(t *testing.T) {
+	assert.Equal(t, "localhost:19021", rttAddress("", jlinkRTTPort))
+	assert.Equal(t, "192.168.1.7:19021", rttAddress("192.168.1.7", jlinkRTTPort))
+	assert.Equal(t, "localhost:19022", rttAddress(":19022", jlinkRTTPort))
+	assert.Equal(t, "box:19022", rttAddress("box:19022", jlinkRTTPort))
+	assert.Equal(t, "[::1]:19021", rttAddress("::1", jlinkRTTPort))
+}
@@ -0,0 +1,48 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/rokath/trice/internal/receiver"
+	"github.com/tj/assert"
+)
+
+func TestSTDIN(t *testing.T) {
+	r, w, err := os.Pipe()
+	assert.Nil(t, err)
+	stdin := os.Stdin
+	defer func() { os.Stdin = stdin }()
+	os.Stdin = r
+	rc, err := receiver.NewReadCloser("STDIN", "default")
+	assert.Nil(t, err)
+	go func() {
+		_, _ = w.Write([]byte{1, 2, 3})
+		_ = w.Close()
+	}()
+	b, err := ioutil.ReadAll(rc) // ends with io.EOF
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, b)
+	assert.Nil(t, rc.Close())
+	assert.Nil(t, r.Close())
+
+	save := receiver.Port
+	defer func() { receiver.Port = save }()
+	receiver.Port = "STDIN"
+	assert.True(t, receiver.Finite())
+	receiver.Port = "FIFO:/tmp/trice.fifo"
+	assert.True(t, receiver.Finite())
+	receiver.Port = "CMD"
+	assert.True(t, receiver.Finite())
+	receiver.Port = "TCP4:localhost:2217"
+	assert.False(t, receiver.Finite())
+}
+
+func TestFIFONotExisting(t *testing.T) {
+	_, err := receiver.NewReadCloser("FIFO:notExistingPipe", "default")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+var (
+	// DialTimeout is the max wait time for establishing a TCP connection.
+	DialTimeout = 3 * time.Second
+
+	// errConnectionClosed is returned instead of io.EOF when the remote side closed a TCP connection.
+	errConnectionClosed = errors.New("connection closed by remote side")
+)
+
+// tcpReadCloser is a TCP client receiver, usable for ser2net, WiFi bridges or any other TCP byte stream source.
+type tcpReadCloser struct {
+	conn net.Conn
+}
+
+// splitNetPort returns for port "TCP4:localhost:2217" network "tcp4" and address "localhost:2217".
+func splitNetPort(port string) (network, address string) {
+	s := strings.SplitN(port, ":", 2)
+	network = strings.ToLower(s[0])
+	if 2 == len(s) {
+		address = s[1]
+	}
+	return
+}
+
+// newTCPReadCloser connects to address over network, which is "tcp4" or "tcp6".
+func newTCPReadCloser(network, address string) (io.ReadCloser, error) {
+	conn, err := net.DialTimeout(network, address, DialTimeout)
+	if nil != err {
+		return nil, err
+	}
+	if Verbose {
+		fmt.Println("Connected to", conn.RemoteAddr(), "from", conn.LocalAddr())
+	}
+	return &tcpReadCloser{conn}, nil
+}
+
+// Read reads from the TCP connection.
+// A remote side connection close is not reported as io.EOF but as error,
+// because the byte stream is not at its end and the caller is expected to re-connect.
+func (p *tcpReadCloser) Read(b []byte) (n int, err error) {
+	n, err = p.conn.Read(b)
+	if io.EOF == err {
+		err = errConnectionClosed
+	}
+	return
+}
+
+// Write sends b over the TCP connection, for example commands to the target behind a ser2net server.
+func (p *tcpReadCloser) Write(b []byte) (int, error) {
+	return p.conn.Write(b)
+}
+
+// Close closes the TCP connection.
+func (p *tcpReadCloser) Close() error {
+	if Verbose {
+		fmt.Println("Closing TCP connection to", p.conn.RemoteAddr())
+	}
+	return p.conn.Close()
+}
@@ -0,0 +1,48 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver_test
+
+import (
+	"io"
+	"net"
+	"testing"
+
+	"github.com/rokath/trice/internal/receiver"
+	"github.com/tj/assert"
+)
+
+func TestTCP4(t *testing.T) {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer func() { assert.Nil(t, ln.Close()) }()
+	go func() {
+		conn, err := ln.Accept()
+		if nil != err {
+			return
+		}
+		_, _ = conn.Write([]byte{1, 2, 3})
+		_ = conn.Close()
+	}()
+
+	rc, err := receiver.NewReadCloser("TCP4:"+ln.Addr().String(), "default")
+	assert.Nil(t, err)
+	b := make([]byte, 100)
+	n, err := io.ReadAtLeast(rc, b, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, b[:n])
+	n, err = rc.Read(b)
+	assert.True(t, 0 == n)
+	assert.NotNil(t, err)
+	assert.True(t, io.EOF != err) // a closed connection is no end of data
+	assert.Nil(t, rc.Close())
+}
+
+func TestTCP4NoServer(t *testing.T) {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	assert.Nil(t, err)
+	addr := ln.Addr().String()
+	assert.Nil(t, ln.Close())
+	_, err = receiver.NewReadCloser("TCP4:"+addr, "default")
+	assert.NotNil(t, err)
+}
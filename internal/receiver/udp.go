@@ -0,0 +1,94 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sync/atomic"
+)
+
+// sender holds the address string of the last received UDP datagram.
+var sender atomic.Value
+
+// Framer is implemented by receivers which are able to deliver exactly one complete frame with each Read, like an UDP datagram.
+// If Framed returns true, a decoder can drop bytes not forming a complete trice at the frame end and is in sync again with the next frame.
+type Framer interface {
+	Framed() bool
+}
+
+// maxDatagramSize is the max UDP datagram size.
+const maxDatagramSize = 65536
+
+// udpReadCloser is an UDP listener receiver for trices sent from networked targets.
+type udpReadCloser struct {
+	conn   *net.UDPConn
+	framed bool
+	buf    []byte // receive buffer for a whole datagram, so that an oversized datagram is detectable
+}
+
+// IsUDP returns true if port is an UDP listener port like "UDP:0.0.0.0:17001".
+func IsUDP(port string) bool {
+	switch portType(port) {
+	case "UDP", "UDP4", "UDP6":
+		return true
+	}
+	return false
+}
+
+// Source returns the name of the actual trice data source.
+// For UDP ports it is the sender address of the last received datagram, otherwise it is the port name.
+func Source() string {
+	if s, ok := sender.Load().(string); ok && IsUDP(Port) {
+		return s
+	}
+	return Port
+}
+
+// newUDPReadCloser listens on address for datagrams over network, which is "udp", "udp4" or "udp6".
+// If args is "frame", each datagram is handled as frame.
+func newUDPReadCloser(network, address, args string) (io.ReadCloser, error) {
+	a, err := net.ResolveUDPAddr(network, address)
+	if nil != err {
+		return nil, err
+	}
+	conn, err := net.ListenUDP(network, a)
+	if nil != err {
+		return nil, err
+	}
+	if Verbose {
+		fmt.Println("Listening for UDP datagrams @", conn.LocalAddr())
+	}
+	return &udpReadCloser{conn: conn, framed: "frame" == args, buf: make([]byte, maxDatagramSize)}, nil
+}
+
+// Read returns the next datagram and remembers its sender address.
+// A datagram longer than len(b) is discarded with an error message, because a truncated frame would be decoded as complete.
+func (p *udpReadCloser) Read(b []byte) (n int, err error) {
+	for {
+		m, addr, err := p.conn.ReadFromUDP(p.buf)
+		if nil != addr {
+			sender.Store(addr.String())
+		}
+		if nil == err && len(b) < m {
+			fmt.Println("err:UDP datagram from", addr, "with", m, "bytes is longer than the read buffer with", len(b), "bytes - discarding it")
+			continue
+		}
+		return copy(b, p.buf[:m]), err
+	}
+}
+
+// Framed returns true, if each datagram is to be handled as frame.
+func (p *udpReadCloser) Framed() bool {
+	return p.framed
+}
+
+// Close stops listening.
+func (p *udpReadCloser) Close() error {
+	if Verbose {
+		fmt.Println("Closing UDP listener @", p.conn.LocalAddr())
+	}
+	return p.conn.Close()
+}
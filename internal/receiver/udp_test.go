@@ -0,0 +1,79 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package receiver_test
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/rokath/trice/internal/receiver"
+	"github.com/rokath/trice/pkg/tst"
+	"github.com/tj/assert"
+)
+
+// freeUDPAddr returns a local UDP address not in use.
+func freeUDPAddr(t *testing.T) string {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	assert.Nil(t, err)
+	addr := conn.LocalAddr().String()
+	assert.Nil(t, conn.Close())
+	return addr
+}
+
+func TestUDP(t *testing.T) {
+	port := "UDP4:" + freeUDPAddr(t)
+	rc, err := receiver.NewReadCloser(port, "frame")
+	assert.Nil(t, err)
+	defer func() { assert.Nil(t, rc.Close()) }()
+	f, ok := rc.(receiver.Framer)
+	assert.True(t, ok)
+	assert.True(t, f.Framed())
+
+	conn, err := net.Dial("udp4", port[len("UDP4:"):])
+	assert.Nil(t, err)
+	defer func() { assert.Nil(t, conn.Close()) }()
+	_, err = conn.Write([]byte{1, 2, 3})
+	assert.Nil(t, err)
+
+	b := make([]byte, 100)
+	n, err := rc.Read(b)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, b[:n])
+
+	save := receiver.Port
+	defer func() { receiver.Port = save }()
+	receiver.Port = port
+	assert.Equal(t, conn.LocalAddr().String(), receiver.Source())
+}
+
+func TestUDPLongDatagram(t *testing.T) {
+	port := "UDP4:" + freeUDPAddr(t)
+	rc, err := receiver.NewReadCloser(port, "frame")
+	assert.Nil(t, err)
+	defer func() { assert.Nil(t, rc.Close()) }()
+	conn, err := net.Dial("udp4", port[len("UDP4:"):])
+	assert.Nil(t, err)
+	defer func() { assert.Nil(t, conn.Close()) }()
+	_, err = conn.Write([]byte{1, 2, 3, 4, 5})
+	assert.Nil(t, err)
+	_, err = conn.Write([]byte{6, 7})
+	assert.Nil(t, err)
+
+	b := make([]byte, 4)
+	var n int
+	act := tst.CaptureStdOut(func() { n, err = rc.Read(b) })
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{6, 7}, b[:n])
+	assert.True(t, strings.Contains(act, "5 bytes is longer than the read buffer with 4 bytes"), act)
+}
+
+func TestUDPNotFramed(t *testing.T) {
+	rc, err := receiver.NewReadCloser("UDP4:"+freeUDPAddr(t), "default")
+	assert.Nil(t, err)
+	f, ok := rc.(receiver.Framer)
+	assert.True(t, ok)
+	assert.False(t, f.Framed())
+	assert.Nil(t, rc.Close())
+}
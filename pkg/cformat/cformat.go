@@ -0,0 +1,156 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+// Package cformat parses C printf format strings into Go format strings and the meaning of the consumed values.
+package cformat
+
+import (
+	"strings"
+)
+
+// Kind is the C printf meaning of a value.
+type Kind int
+
+const (
+	None     Kind = iota // not a C conversion, the value is used unchanged
+	Signed               // %d %i
+	Unsigned             // %u
+	Hex                  // %x %X %o %b
+	Float                // %e %E %f %F %g %G %a %A
+	Char                 // %c
+	Pointer              // %p
+	String               // %s
+	Star                 // * as width or precision
+)
+
+// Arg describes a value consumed by a format specifier.
+type Arg struct {
+	Kind Kind
+	Bits int // 8 for the length modifier hh and 16 for h, otherwise 0 for the value bit width
+}
+
+// Format is a parsed C printf format string.
+type Format struct {
+	Strg  string // C format string
+	GoFmt string // equivalent Go format string
+	Args  []Arg  // consumed values
+}
+
+// verbs maps the C conversion characters to the Go verbs and the value meaning.
+// The Go specific %b is accepted too.
+var verbs = map[byte]struct {
+	verb string
+	kind Kind
+}{
+	'd': {"d", Signed},
+	'i': {"d", Signed},
+	'u': {"d", Unsigned},
+	'x': {"x", Hex},
+	'X': {"X", Hex},
+	'o': {"o", Hex},
+	'b': {"b", Hex},
+	'e': {"e", Float},
+	'E': {"E", Float},
+	'f': {"f", Float},
+	'F': {"F", Float},
+	'g': {"g", Float},
+	'G': {"G", Float},
+	'a': {"x", Float},
+	'A': {"X", Float},
+	'c': {"c", Char},
+	'p': {"x", Pointer},
+	's': {"s", String},
+}
+
+// Parse parses the C printf format string s and returns it as Format.
+//
+// Supported are the flags `-+ 0#`, a width and precision as number or `*`, the length modifiers `hh h l ll z j t L`
+// and the conversions `d i u x X o e E f F g G a A c p s %%` as well as the Go `%b`. The grouping flag `'` is ignored.
+// Length modifiers besides hh and h do not matter, because the value bit width is given by the caller.
+// Other verbs are passed to the Go formatting unchanged.
+func Parse(s string) *Format {
+	f := &Format{Strg: s}
+	var g strings.Builder
+	for i := 0; i < len(s); i++ {
+		if '%' != s[i] {
+			g.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && '%' == s[i+1] {
+			g.WriteString("%%")
+			i++
+			continue
+		}
+		j := i + 1
+		var flags string
+		for ; j < len(s) && strings.IndexByte("-+ 0#'", s[j]) >= 0; j++ {
+			if '\'' != s[j] {
+				flags += s[j : j+1]
+			}
+		}
+		var width, prec string
+		j, width = f.number(s, j)
+		if j < len(s) && '.' == s[j] {
+			j, prec = f.number(s, j+1)
+			prec = "." + prec
+		}
+		var bits int
+		for _, m := range []string{"hh", "h", "ll", "l", "z", "j", "t", "L"} {
+			if strings.HasPrefix(s[j:], m) {
+				switch m {
+				case "hh":
+					bits = 8
+				case "h":
+					bits = 16
+				}
+				j += len(m)
+				break
+			}
+		}
+		if j == len(s) { // no verb
+			g.WriteString(s[i:])
+			break
+		}
+		v, ok := verbs[s[j]]
+		if !ok {
+			v.verb, v.kind = s[j:j+1], None
+		}
+		switch s[j] {
+		case 'p':
+			flags += "#"
+		case 'g', 'G':
+			if "" == prec {
+				prec = ".6" // the C default, Go uses the shortest representation
+			}
+		}
+		g.WriteString("%" + flags + width + prec + v.verb)
+		f.Args = append(f.Args, Arg{v.kind, bits})
+		i = j
+	}
+	f.GoFmt = g.String()
+	return f
+}
+
+// number returns the index behind the width or precision starting at s[i] and the width or precision.
+// A `*` adds a value.
+func (f *Format) number(s string, i int) (int, string) {
+	if i < len(s) && '*' == s[i] {
+		f.Args = append(f.Args, Arg{Kind: Star})
+		return i + 1, "*"
+	}
+	j := i
+	for ; j < len(s) && '0' <= s[j] && s[j] <= '9'; j++ {
+	}
+	return j, s[i:j]
+}
+
+// Count returns the count of values the C printf function consumes with f, including the `*` values.
+// Unknown verbs are not counted.
+func (f *Format) Count() (n int) {
+	for _, a := range f.Args {
+		if None != a.Kind {
+			n++
+		}
+	}
+	return
+}
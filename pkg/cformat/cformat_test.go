@@ -0,0 +1,48 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package cformat
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func TestParse(t *testing.T) {
+	for _, x := range []struct {
+		strg, goFmt string
+		args        []Arg
+	}{
+		{"no values", "no values", nil},
+		{"100%% %d%%", "100%% %d%%", []Arg{{Signed, 0}}},
+		{"%-+ 05d %i %u", "%-+ 05d %d %d", []Arg{{Signed, 0}, {Signed, 0}, {Unsigned, 0}}},
+		{"%*d %-*.*x", "%*d %-*.*x", []Arg{{Star, 0}, {Signed, 0}, {Star, 0}, {Star, 0}, {Hex, 0}}},
+		{"%hhd %hu %ld %llx %zu %jd %td %Lf", "%d %d %d %x %d %d %d %f", []Arg{{Signed, 8}, {Unsigned, 16}, {Signed, 0}, {Hex, 0}, {Unsigned, 0}, {Signed, 0}, {Signed, 0}, {Float, 0}}},
+		{"%e %.2E %g %.3G %a", "%e %.2E %.6g %.3G %x", []Arg{{Float, 0}, {Float, 0}, {Float, 0}, {Float, 0}, {Float, 0}}},
+		{"%p %08p %c %s %'d", "%#x %0#8x %c %s %d", []Arg{{Pointer, 0}, {Pointer, 0}, {Char, 0}, {String, 0}, {Signed, 0}}},
+		{"%#o %#X %b %v", "%#o %#X %b %v", []Arg{{Hex, 0}, {Hex, 0}, {Hex, 0}, {None, 0}}},
+		{"end %", "end %", nil},
+		{"end %l", "end %l", nil},
+	} {
+		f := Parse(x.strg)
+		assert.Equal(t, x.goFmt, f.GoFmt, x.strg)
+		assert.Equal(t, x.args, f.Args, x.strg)
+	}
+}
+
+func TestCount(t *testing.T) {
+	for _, x := range []struct {
+		strg string
+		n    int
+	}{
+		{"no values %%d\n", 0},
+		{"%i %ld\n", 2},
+		{"%-5d %+d", 2},
+		{"%*d", 2},
+		{"%hhx %p", 2},
+		{"%3.1f %s %v", 2},
+	} {
+		assert.Equal(t, x.n, Parse(x.strg).Count(), x.strg)
+	}
+}
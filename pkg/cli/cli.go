@@ -0,0 +1,21 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+// Package cli provides the trice tool command line interface for main packages.
+//
+// A main package outside this module builds a trice binary with additional encodings
+// by importing the encoding packages and calling Run, see package github.com/rokath/trice/pkg/decoder.
+package cli
+
+import (
+	"github.com/rokath/trice/internal/args"
+)
+
+// Run performs the trice command given in osArgs like os.Args.
+// version, commit and date are displayed by 'trice version'. Empty values display a development version.
+func Run(osArgs []string, version, commit, date string) error {
+	args.Version = version
+	args.Commit = commit
+	args.Date = date
+	return args.Handler(osArgs)
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+// Package decoder lets packages outside this module add encodings to the trice tool.
+//
+// An encoding package registers its decoder constructor in an init function. The decoder embeds Base,
+// which does the input buffering, the ID look-up and the rendering like the built-in decoders:
+//
+//	type myDecoder struct {
+//		decoder.Base
+//	}
+//
+//	func newMyDecoder(lut decoder.TriceIDLookUp, m *sync.RWMutex, in io.Reader, endian bool) decoder.Decoder {
+//		p := &myDecoder{}
+//		p.Init(lut, m, in, endian)
+//		return p
+//	}
+//
+//	func (p *myDecoder) Read(b []byte) (int, error) {
+//		return p.ReadTrice(b, p.interpret)
+//	}
+//
+//	func init() {
+//		msg.FatalOnErr(decoder.Register(decoder.Registration{Name: "my", Description: "...", New: newMyDecoder, BigEndian: true}))
+//	}
+//
+// The interpret method decodes the trice at the start of p.Buffer(), see the example.
+//
+// A trice binary with the encoding is built from an own main package, which imports the encoding package and calls cli.Run:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"os"
+//
+//		"github.com/rokath/trice/pkg/cli"
+//		_ "example.com/company/triceencoding" // registers the encoding
+//	)
+//
+//	func main() {
+//		if err := cli.Run(os.Args, "", "", ""); nil != err {
+//			fmt.Print(err)
+//		}
+//	}
+//
+// Then 'go build' creates a trice binary knowing 'trice log -encoding my'.
+package decoder
+
+import (
+	"github.com/rokath/trice/internal/decoder"
+	"github.com/rokath/trice/internal/id"
+)
+
+type (
+	// Decoder is a trice decoder. Read renders one trice into its parameter and returns the byte count.
+	Decoder = decoder.Decoder
+
+	// NewDecoder is the constructor type for a decoder. in is the byte stream to decode and
+	// endian is BigEndian or LittleEndian according to the selected encoding name.
+	NewDecoder = decoder.NewDecoder
+
+	// Registration describes an encoding for Register.
+	Registration = decoder.Registration
+
+	// Base is the common part of a decoder. A decoder embeds it.
+	Base = decoder.Base
+
+	// TriceID is a trice identifier.
+	TriceID = id.TriceID
+
+	// TriceFmt is the trice type and format string of a trice ID.
+	TriceFmt = id.TriceFmt
+
+	// TriceIDLookUp is the ID list.
+	TriceIDLookUp = id.TriceIDLookUp
+)
+
+const (
+	// BigEndian is the endian parameter value for NewDecoder for big endian (network order) byte streams.
+	BigEndian = decoder.BigEndian
+
+	// LittleEndian is the endian parameter value for NewDecoder for little endian byte streams.
+	LittleEndian = decoder.LittleEndian
+)
+
+// Register adds an encoding. It returns an error if a name is used already or if the registration is incomplete.
+func Register(r Registration) error {
+	return decoder.Register(r)
+}
+
+// Lookup returns the registration and the endianness for the encoding name.
+func Lookup(name string) (r Registration, endian bool, err error) {
+	return decoder.Lookup(name)
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 Thomas.Hoehenleitner [at] seerose.net
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+// blackbox test
+package decoder_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/rokath/trice/pkg/decoder"
+	"github.com/rokath/trice/pkg/msg"
+)
+
+// id16 is an encoding with a 16-bit ID followed by a 16-bit value.
+type id16 struct {
+	decoder.Base
+}
+
+func newID16(lut decoder.TriceIDLookUp, m *sync.RWMutex, in io.Reader, endian bool) decoder.Decoder {
+	p := &id16{}
+	p.Init(lut, m, in, endian)
+	return p
+}
+
+func (p *id16) Read(b []byte) (int, error) {
+	return p.ReadTrice(b, p.interpret)
+}
+
+// interpret renders the trice at the buffer start.
+func (p *id16) interpret() (int, error) {
+	b := p.Buffer()
+	if len(b) < 4 {
+		return 0, nil // wait
+	}
+	triceID := decoder.TriceID(p.ReadU16(b[0:2]))
+	t, ok := p.Trice(triceID)
+	if !ok {
+		return p.OutOfSync("unknown ID", triceID)
+	}
+	v := int16(p.ReadU16(b[2:4]))
+	p.Rub(4)
+	return p.Sprint(t.Strg, v), nil
+}
+
+func init() {
+	msg.FatalOnErr(decoder.Register(decoder.Registration{
+		Name:         "id16",
+		Description:  "16-bit ID and 16-bit value.",
+		New:          newID16,
+		BigEndian:    true,
+		LittleEndian: true,
+	}))
+}
+
+func Example() {
+	lut := decoder.TriceIDLookUp{
+		1: {Type: "TRICE16_1", Strg: "v=%d\n"},
+		2: {Type: "TRICE16_1", Strg: "u=%u\n"},
+	}
+	r, endian, err := decoder.Lookup("id16L")
+	msg.FatalOnErr(err)
+	in := []byte{1, 0, 0xfe, 0xff, 2, 0, 0xfe, 0xff, 3, 0}
+	dec := r.New(lut, new(sync.RWMutex), bytes.NewReader(in), endian)
+	b := make([]byte, 4096)
+	for {
+		n, err := dec.Read(b)
+		fmt.Print(string(b[:n]))
+		if io.EOF == err && 0 == n {
+			break
+		}
+	}
+	// Output:
+	// v=-2
+	// u=65534
+}
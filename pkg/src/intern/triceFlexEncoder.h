@@ -678,6 +678,46 @@ TRICE_INLINE void trice_s(uint32_t id, char *s) {
 #define TRICE_S(id, pFmt, dynString) do{ trice_s(id, dynString); }while(0)
 
 
+//! buffer transfer format like string transfer format:
+//!     id       count    cycle <--- id value in trice transfer order
+//! b0     b1     b2       b3
+//! ...
+//! bLen-3 bLen-2 bLen-1   bLen <--- last bytes followed by 0 padding bytes
+TRICE_INLINE void trice_b(uint32_t id, const uint8_t *b, int len) {
+    TRICE_ENTER_CRITICAL_SECTION
+    int i = 0;
+    if( 65535 < len ){
+        len = 65535; // truncate
+    }
+    if( len <= 4 ){
+        TRICE_HTON_U32PUSH( id|(len<<8)|triceCycle ); // on PC side the id reception gives the TRICE_B and the format string information
+    }else{
+        TRICE_HTON_U32PUSH( id|(0x7<<8)|triceCycle ); // on PC side the id reception gives the TRICE_B and the format string information
+        TRICE_HTON_U32PUSH( TRICE_LONGCOUNT(len) );
+    }
+    triceCycle++;
+    while( 3 < len ){
+        uint32_t w;
+        memcpy( &w, b+i, 4 ); // b can be unaligned
+        TRICE_U32PUSH( w ); // bytes in memory order like for TRICE_S
+        len -= 4;
+        i += 4;
+    }
+    if( len ){
+        uint32_t w = 0;
+        memcpy( &w, b+i, len ); // last bytes followed by 0 padding bytes in memory order
+        TRICE_U32PUSH( w );
+    }
+    TRICE_LEAVE_CRITICAL_SECTION
+}
+
+//! Write id and len bytes from buf protected (outside critical section).
+//! \param id trice identifier
+//! \param pFmt formatstring for trice (ignored here but used by the trice tool), like "rx: %02x\n" or "rx:\n%xxd\n" for a hex dump
+//! \param buf byte buffer
+//! \param len byte count, max 65535
+#define TRICE_B(id, pFmt, buf, len) do{ trice_b(id, (const uint8_t*)(buf), len); }while(0)
+
 #ifdef __cplusplus
 }
 #endif
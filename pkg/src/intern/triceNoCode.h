@@ -30,6 +30,7 @@ extern "C" {
 #define TRICE32_4( id, pFmt, v0, v1, v2, v3 )
 #define TRICE64_1( id, pFmt, v0 )
 #define TRICE64_2( id, pFmt, v0, v1 )
+#define TRICE_B( id, pFmt, buf, len )
 
 #endif // #ifdef TRICE_NO_CODE // no trice code generation ////////////////////
 
@@ -216,6 +216,47 @@ int triceU32WriteU8ReadFifoDepth(void);
 #include "intern/triceFlexEncoder.h"
 #endif
 
+//! aFloat returns the bits of x for the transmission as TRICE32F value.
+TRICE_INLINE uint32_t aFloat( float x ){
+    union { float f; uint32_t u; } t;
+    t.f = x;
+    return t.u;
+}
+
+//! aDouble returns the bits of x for the transmission as TRICE64F value.
+TRICE_INLINE uint64_t aDouble( double x ){
+    union { double d; uint64_t u; } t;
+    t.d = x;
+    return t.u;
+}
+
+// The TRICE32F and TRICE64F trices transmit float and double values, which the trice tool displays with %f, %e or %g.
+#define TRICE32F_1( id, pFmt, v0 )             TRICE32_1( id, pFmt, aFloat(v0) )
+#define TRICE32F_2( id, pFmt, v0, v1 )         TRICE32_2( id, pFmt, aFloat(v0), aFloat(v1) )
+#define TRICE32F_3( id, pFmt, v0, v1, v2 )     TRICE32_3( id, pFmt, aFloat(v0), aFloat(v1), aFloat(v2) )
+#define TRICE32F_4( id, pFmt, v0, v1, v2, v3 ) TRICE32_4( id, pFmt, aFloat(v0), aFloat(v1), aFloat(v2), aFloat(v3) )
+#define TRICE64F_1( id, pFmt, v0 )             TRICE64_1( id, pFmt, aDouble(v0) )
+#define TRICE64F_2( id, pFmt, v0, v1 )         TRICE64_2( id, pFmt, aDouble(v0), aDouble(v1) )
+
+#define TRICE32F_1i( id, pFmt, v0 )             TRICE32_1i( id, pFmt, aFloat(v0) )
+#define TRICE32F_2i( id, pFmt, v0, v1 )         TRICE32_2i( id, pFmt, aFloat(v0), aFloat(v1) )
+#define TRICE32F_3i( id, pFmt, v0, v1, v2 )     TRICE32_3i( id, pFmt, aFloat(v0), aFloat(v1), aFloat(v2) )
+#define TRICE32F_4i( id, pFmt, v0, v1, v2, v3 ) TRICE32_4i( id, pFmt, aFloat(v0), aFloat(v1), aFloat(v2), aFloat(v3) )
+#define TRICE64F_1i( id, pFmt, v0 )             TRICE64_1i( id, pFmt, aDouble(v0) )
+#define TRICE64F_2i( id, pFmt, v0, v1 )         TRICE64_2i( id, pFmt, aDouble(v0), aDouble(v1) )
+
+#define TRICE32F_COUNT(_1,_2,_3,_4, NAME,...) NAME
+#define TRICE32F(id,frmt, ...) TRICE32F_COUNT(__VA_ARGS__,TRICE32F_4,TRICE32F_3,TRICE32F_2,TRICE32F_1)(id,frmt, __VA_ARGS__)
+
+#define TRICE64F_COUNT(_1,_2, NAME,...) NAME
+#define TRICE64F(id,frmt, ...) TRICE64F_COUNT(__VA_ARGS__,TRICE64F_2,TRICE64F_1)(id,frmt, __VA_ARGS__)
+
+#define TRICE32F_COUNTi(_1i,_2i,_3i,_4i, NAME,...) NAME
+#define TRICE32Fi(id,frmt, ...) TRICE32F_COUNTi(__VA_ARGS__,TRICE32F_4i,TRICE32F_3i,TRICE32F_2i,TRICE32F_1i)(id,frmt, __VA_ARGS__)
+
+#define TRICE64F_COUNTi(_1i,_2i, NAME,...) NAME
+#define TRICE64Fi(id,frmt, ...) TRICE64F_COUNTi(__VA_ARGS__,TRICE64F_2i,TRICE64F_1i)(id,frmt, __VA_ARGS__)
+
 #ifndef TRICE_SYNC // some encoder define a sync trice
 #define TRICE_SYNC do{ } while(0)// otherwise empty definition for compability
 #endif